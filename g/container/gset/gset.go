@@ -10,14 +10,57 @@
 package gset
 
 import (
+    "bufio"
+    "bytes"
+    "database/sql/driver"
+    "encoding/gob"
+    "encoding/json"
+    "github.com/gogf/gf/g/encoding/ghash"
     "github.com/gogf/gf/g/internal/rwmutex"
     "github.com/gogf/gf/g/util/gconv"
+    "github.com/gogf/gf/g/util/grand"
+    "hash/fnv"
+    "io"
+    "reflect"
+    "runtime"
+    "sort"
     "strings"
+    "sync"
+    "sync/atomic"
 )
 
+const (
+    // 集合未冻结
+    gFROZEN_NONE   = 0
+    // 集合已冻结, 修改时panic
+    gFROZEN_PANIC  = 1
+    // 集合已冻结, 修改时忽略
+    gFROZEN_SILENT = 2
+)
+
+// Set is a set of interface{} items.
+// The new sets returned by the set operations like Union, Diff, Intersect and Complement
+// have the same concurrent-safety as the current set.
+//
+// interface{}类型的集合. Union/Diff/Intersect/Complement等集合运算返回的新集合与当前集合的并发安全性相同.
 type Set struct {
-    mu *rwmutex.RWMutex
-    m  map[interface{}]struct{}
+    mu        *rwmutex.RWMutex
+    m         map[interface{}]struct{}
+    listeners []func(added, removed interface{}) // 元素项变更回调函数列表
+    watchers  []*sizeWatcher                     // 集合大小阈值监听列表
+    validator func(v interface{}) error          // 元素项校验函数
+    frozen    int32                              // 冻结状态(gFROZEN_NONE/gFROZEN_PANIC/gFROZEN_SILENT)
+    shared    bool                               // 底层map是否可能与其他集合共享(写时复制)
+}
+
+// sizeWatcher is the size threshold callback registered by OnSizeExceed.
+//
+// 通过OnSizeExceed注册的集合大小阈值监听.
+type sizeWatcher struct {
+    threshold int            // 集合大小阈值
+    f         func(size int) // 超过阈值时的回调函数
+    rearm     bool           // 集合大小回落到阈值以内后是否重新启用
+    fired     bool           // 是否已经触发过
 }
 
 // Create a set, which contains un-repeated items.
@@ -39,6 +82,84 @@ func NewSet(unsafe...bool) *Set {
     }
 }
 
+// NewSize creates a set with a capacity hint <size>, which pre-allocates the underlying map
+// to avoid repeated growth when bulk-loading lots of items.
+//
+// 创建一个预分配容量为size的集合, 用于避免批量添加大量元素项时底层map的重复扩容.
+func NewSize(size int, unsafe...bool) *Set {
+    return &Set{
+        m  : make(map[interface{}]struct{}, size),
+        mu : rwmutex.New(unsafe...),
+    }
+}
+
+// Of creates a concurrent-safe set containing the given <items>, which is equivalent to New().Add(items...).
+//
+// 创建包含给定元素项的并发安全集合, 等同于New().Add(items...).
+func Of(items...interface{}) *Set {
+    return New().Add(items...)
+}
+
+// NewFrom creates a set from given slice <items>, which can be a slice of any type.
+// It returns an empty set if <items> is nil or not a slice.
+//
+// 使用给定的任意类型slice创建集合, items为nil或者不是slice时返回空集合.
+func NewFrom(items interface{}, unsafe...bool) *Set {
+    set := NewSet(unsafe...)
+    if items == nil {
+        return set
+    }
+    switch reflect.ValueOf(items).Kind() {
+        case reflect.Slice, reflect.Array:
+            for _, v := range gconv.Interfaces(items) {
+                set.m[v] = struct{}{}
+            }
+    }
+    return set
+}
+
+// NewFromAny creates a set from any given <value>, which can be a slice of any type,
+// a JSON array in string or []byte, or a string joined by char ','.
+// Other types of <value> are added as a single item.
+// It returns an empty set if <value> is nil or is an invalid JSON array.
+//
+// 使用任意类型的value创建集合, value可以为任意类型的slice, string/[]byte类型的JSON数组, 或者使用','连接的字符串.
+// 其他类型的value作为单个元素项添加. value为nil或者为非法的JSON数组时返回空集合.
+func NewFromAny(value interface{}, unsafe...bool) *Set {
+    set := NewSet(unsafe...)
+    switch value.(type) {
+        case nil:
+        case string, []byte:
+            set.Scan(value)
+        default:
+            for _, v := range gconv.Interfaces(value) {
+                set.m[v] = struct{}{}
+            }
+    }
+    return set
+}
+
+// NewFromLines creates a set from reader <r> line by line, each line is trimmed and added as a string item,
+// and the blank lines are skipped. It reads <r> in streaming, so it's suitable for large inputs.
+// The param <unsafe> used to specify whether using array with un-concurrent-safety,
+// which is false in default, means concurrent-safe in default.
+//
+// 逐行读取r并创建集合, 每一行去除首尾空白后作为string类型元素项添加, 空行会被忽略. 采用流式读取, 适用于较大的输入.
+// 参数unsafe用于指定是否用于非并发安全场景，默认为false，表示并发安全。
+func NewFromLines(r io.Reader, unsafe...bool) (*Set, error) {
+    set     := NewSet(unsafe...)
+    scanner := bufio.NewScanner(r)
+    for scanner.Scan() {
+        if line := strings.TrimSpace(scanner.Text()); line != "" {
+            set.m[line] = struct{}{}
+        }
+    }
+    if err := scanner.Err(); err != nil {
+        return nil, err
+    }
+    return set, nil
+}
+
 // Iterate the set by given callback <f>,
 // if <f> returns true then continue iterating; or false to stop.
 //
@@ -54,221 +175,1753 @@ func (set *Set) Iterator(f func (v interface{}) bool) *Set {
     return set
 }
 
-// Add one or multiple items to the set.
+// IteratorIndexed iterates the set by given callback <f> like Iterator,
+// but also passes the zero-based <index> of the item in this iteration to <f>.
 //
-// 添加元素项到集合中(支持多个).
-func (set *Set) Add(item...interface{}) *Set {
-    set.mu.Lock()
-    for _, v := range item {
-        set.m[v] = struct{}{}
+// 同Iterator, 但同时会将元素项在本次遍历中的序号index(从0开始)传递给回调函数。
+func (set *Set) IteratorIndexed(f func (index int, v interface{}) bool) *Set {
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    index := 0
+    for k := range set.m {
+        if !f(index, k) {
+            break
+        }
+        index++
     }
-    set.mu.Unlock()
     return set
 }
 
-// Check whether the set contains <item>.
+// IteratorMutable iterates the set by given callback <f>, the items for which <f> returns false
+// are removed from the set after the iteration, so it's a safe filter-in-place.
+// Note that <f> is called within the read lock like Iterator, and the removals are applied
+// by Remove after the read lock is released, so it's not atomic.
 //
-// 键是否存在.
-func (set *Set) Contains(item interface{}) bool {
+// 给定回调函数对原始内容进行遍历，回调函数返回false的元素项会在遍历结束后从集合中删除(安全的原地过滤).
+// 注意回调函数与Iterator一样在读锁内执行, 删除操作在释放读锁后通过Remove执行, 因此并不是原子操作.
+func (set *Set) IteratorMutable(f func (v interface{}) (keep bool)) *Set {
+    var removed []interface{}
     set.mu.RLock()
-    _, exists := set.m[item]
+    for k := range set.m {
+        if !f(k) {
+            removed = append(removed, k)
+        }
+    }
     set.mu.RUnlock()
-    return exists
+    if len(removed) > 0 {
+        set.Remove(removed...)
+    }
+    return set
 }
 
-// Remove <item> from set.
+// IteratorSnapshot iterates a snapshot of the set by given callback <f>,
+// if <f> returns true then continue iterating; or false to stop.
+// Unlike Iterator, the lock is only held while copying the items,
+// so <f> can safely call the methods of the set, and the concurrent changes are not observed.
 //
-// 删除元素项。
-func (set *Set) Remove(item interface{}) *Set {
-    set.mu.Lock()
-    delete(set.m, item)
-    set.mu.Unlock()
+// 给定回调函数对集合的快照进行遍历，回调函数返回true表示继续遍历，否则停止遍历。
+// 与Iterator不同的是, 仅在复制元素项时加锁, 因此回调函数中可以安全地调用当前集合的方法, 且不会感知遍历期间的并发修改.
+func (set *Set) IteratorSnapshot(f func (v interface{}) bool) *Set {
+    for _, v := range set.Slice() {
+        if !f(v) {
+            break
+        }
+    }
     return set
 }
 
-// Get size of the set.
+// ForEachParallel calls callback function <f> for each item of a snapshot of the set
+// using <workers> goroutines, and blocks until all items are processed.
+// The <workers> uses the count of CPUs if it's less than 1.
+// Note that <f> is called concurrently, so it must be concurrent-safe.
 //
-// 获得集合大小。
-func (set *Set) Size() int {
-    set.mu.RLock()
-    l := len(set.m)
-    set.mu.RUnlock()
-    return l
+// 使用workers个goroutine并行地对集合快照中的每个元素项调用回调函数f, 并阻塞直到所有元素项处理完成.
+// workers小于1时使用CPU数量. 注意f会被并发调用, 因此f必须是并发安全的.
+func (set *Set) ForEachParallel(workers int, f func(v interface{})) *Set {
+    if workers < 1 {
+        workers = runtime.NumCPU()
+    }
+    array := set.Slice()
+    if workers > len(array) {
+        workers = len(array)
+    }
+    ch := make(chan interface{}, len(array))
+    for _, v := range array {
+        ch <- v
+    }
+    close(ch)
+    wg := sync.WaitGroup{}
+    for i := 0; i < workers; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for v := range ch {
+                f(v)
+            }
+        }()
+    }
+    wg.Wait()
+    return set
 }
 
-// Clear the set.
+// Walk applies callback function <f> to every item of the set in place.
+// Items mapped to the same result are collapsed into one item.
 //
-// 清空集合。
-func (set *Set) Clear() *Set {
+// 使用回调函数f对集合中的每个元素项进行处理(原地修改), 处理结果相同的元素项会合并为一项.
+func (set *Set) Walk(f func(item interface{}) interface{}) *Set {
+    if !set.writable() {
+        return set
+    }
+    var changes setChanges
+    defer changes.notify()
     set.mu.Lock()
-    set.m = make(map[interface{}]struct{})
-    set.mu.Unlock()
+    defer set.mu.Unlock()
+    changes = set.changes()
+    defer changes.commit()
+    m := make(map[interface{}]struct{}, len(set.m))
+    for k, v := range set.m {
+        if item := f(k); set.valid(item) {
+            m[item] = v
+        }
+    }
+    changes.replace(set.m, m)
+    set.m      = m
+    set.shared = false
     return set
 }
 
-// Get the copy of items from set as slice.
+// Filter returns a new concurrent-safe set containing the items of current set
+// for which callback function <f> returns true.
 //
-// 获得集合元素项列表.
-func (set *Set) Slice() []interface{} {
+// 返回由回调函数f返回true的元素项组成的新并发安全集合.
+func (set *Set) Filter(f func(v interface{}) bool) *Set {
+    newSet := NewSet()
     set.mu.RLock()
-    i   := 0
-    ret := make([]interface{}, len(set.m))
-    for item := range set.m {
-        ret[i] = item
-        i++
+    defer set.mu.RUnlock()
+    for k, v := range set.m {
+        if f(k) {
+            newSet.m[k] = v
+        }
     }
-    set.mu.RUnlock()
-    return ret
+    return newSet
 }
 
-// Join set items with a string.
+// Map returns a new concurrent-safe set containing the results of callback function <f>
+// applied to each item of current set. Note that the new set may be smaller than
+// current set if <f> maps different items to the same result.
 //
-// 使用glue字符串串连当前集合的元素项，构造成新的字符串返回。
-func (set *Set) Join(glue string) string {
-    return strings.Join(gconv.Strings(set.Slice()), ",")
+// 返回由回调函数f处理每个元素项后的结果组成的新并发安全集合.
+// 注意当f将不同的元素项映射为相同的结果时, 新集合的大小会小于当前集合.
+func (set *Set) Map(f func(v interface{}) interface{}) *Set {
+    newSet := NewSet()
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    for k, v := range set.m {
+        newSet.m[f(k)] = v
+    }
+    return newSet
 }
 
-// Return set items as a string, which are joined by char ','.
+// Partition splits the set into two new concurrent-safe sets by callback function <f> in one pass,
+// <matched> contains the items for which <f> returns true, and <unmatched> contains the others.
 //
-// 使用glue字符串串连当前集合的元素项，构造成新的字符串返回。
-func (set *Set) String() string {
-    return set.Join(",")
+// 使用回调函数f将集合一次性拆分为两个新的并发安全集合, matched为f返回true的元素项, unmatched为其余元素项.
+func (set *Set) Partition(f func(v interface{}) bool) (matched, unmatched *Set) {
+    matched   = NewSet()
+    unmatched = NewSet()
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    for k, v := range set.m {
+        if f(k) {
+            matched.m[k] = v
+        } else {
+            unmatched.m[k] = v
+        }
+    }
+    return
 }
 
-// Lock writing by callback function f.
+// GroupBy groups the items of the set by the keys computed by callback function <key>,
+// each group is a new concurrent-safe set.
 //
-// 使用自定义方法执行加锁修改操作。
-func (set *Set) LockFunc(f func(m map[interface{}]struct{})) *Set {
-    set.mu.Lock(true)
-    defer set.mu.Unlock(true)
-    f(set.m)
+// 使用回调函数key计算的键名对集合元素项进行分组, 每个分组为一个新的并发安全集合.
+func (set *Set) GroupBy(key func(v interface{}) interface{}) map[interface{}]*Set {
+    groups := make(map[interface{}]*Set)
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    for k, v := range set.m {
+        groupKey := key(k)
+        group, ok := groups[groupKey]
+        if !ok {
+            group = NewSet()
+            groups[groupKey] = group
+        }
+        group.m[k] = v
+    }
+    return groups
+}
+
+// CountBy counts the items of the set by the keys computed by callback function <key>,
+// which is like GroupBy but only returns the count of each group.
+//
+// 使用回调函数key计算的键名对集合元素项进行分组计数, 与GroupBy类似但只返回每个分组的元素项数量.
+func (set *Set) CountBy(key func(v interface{}) interface{}) map[interface{}]int {
+    counts := make(map[interface{}]int)
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    for k := range set.m {
+        counts[key(k)]++
+    }
+    return counts
+}
+
+// Shard partitions the items of the set into <n> sub-sets by the hash of the items in string form,
+// the same item is always in the same sub-set, and the empty sub-sets are also returned.
+// It returns nil if <n> is less than 1.
+//
+// 按照元素项字符串形式的哈希值将集合元素项划分到n个子集合中, 相同的元素项总是位于同一个子集合,
+// 空的子集合同样会被返回. n小于1时返回nil.
+func (set *Set) Shard(n int) []*Set {
+    if n < 1 {
+        return nil
+    }
+    shards := make([]*Set, n)
+    for i := 0; i < n; i++ {
+        shards[i] = NewSet(!set.mu.IsSafe())
+    }
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    for k, v := range set.m {
+        shards[ghash.BKDRHash([]byte(gconv.String(k))) % uint32(n)].m[k] = v
+    }
+    return shards
+}
+
+// Add one or multiple items to the set.
+// The items failing the validator set by SetValidator are skipped.
+//
+// 添加元素项到集合中(支持多个), 未通过SetValidator设置的校验函数校验的元素项会被忽略.
+func (set *Set) Add(item...interface{}) *Set {
+    set.doAdd(item, false)
     return set
 }
 
-// Lock reading by callback function f.
+// AddSafe adds one or multiple items to the set like Add, but if any of the items fails
+// the validator set by SetValidator, it adds none of the items and returns the first validation error.
 //
-// 使用自定义方法执行加锁读取操作。
-func (set *Set) RLockFunc(f func(m map[interface{}]struct{})) *Set {
-    set.mu.RLock(true)
-    defer set.mu.RUnlock(true)
-    f(set.m)
+// 添加元素项到集合中(支持多个), 当任意元素项未通过SetValidator设置的校验函数校验时,
+// 不添加任何元素项并返回第一个校验错误.
+func (set *Set) AddSafe(item...interface{}) error {
+    return set.doAdd(item, true)
+}
+
+// doAdd adds <items> to the set within the write lock, in which the items are validated as well,
+// so that the validator changed concurrently by SetValidator is never bypassed.
+// If <all> is true, it adds none of the items and returns the first validation error if any of them fails,
+// or else it just skips the items failing the validator.
+//
+// 在写锁内校验并添加元素项到集合中, 保证不会绕过通过SetValidator并发修改的校验函数.
+// all为true时, 任意元素项未通过校验则不添加任何元素项并返回第一个校验错误, 否则仅忽略未通过校验的元素项.
+func (set *Set) doAdd(items []interface{}, all bool) error {
+    if !set.writable() {
+        return nil
+    }
+    set.mu.Lock()
+    if all && set.validator != nil {
+        for _, v := range items {
+            if err := set.validator(v); err != nil {
+                set.mu.Unlock()
+                return err
+            }
+        }
+    }
+    set.own()
+    changes := set.changes()
+    for _, v := range items {
+        if !all && !set.valid(v) {
+            continue
+        }
+        changes.add(set.m, v)
+    }
+    changes.commit()
+    set.mu.Unlock()
+    changes.notify()
+    return nil
+}
+
+// AddSlice adds all the items of slice <items> to the set.
+//
+// 添加slice中的所有元素项到集合中.
+func (set *Set) AddSlice(items []interface{}) *Set {
+    if !set.writable() {
+        return set
+    }
+    set.mu.Lock()
+    set.own()
+    changes := set.changes()
+    for _, v := range items {
+        if !set.valid(v) {
+            continue
+        }
+        changes.add(set.m, v)
+    }
+    changes.commit()
+    set.mu.Unlock()
+    changes.notify()
     return set
 }
 
-// Check whether the two sets equal.
+// AddString splits <str> by <sep> and adds each trimmed token to the set as string item,
+// the empty tokens are ignored. It's the reverse operation of Join.
 //
-// 判断两个集合是否相等.
-func (set *Set) Equal(other *Set) bool {
-    if set == other {
+// 使用sep分隔字符串str, 并将去除首尾空白后的每一项作为字符串元素项添加到集合中(忽略空项), 为Join的逆操作.
+func (set *Set) AddString(str, sep string) *Set {
+    if !set.writable() {
+        return set
+    }
+    set.mu.Lock()
+    set.own()
+    changes := set.changes()
+    for _, v := range strings.Split(str, sep) {
+        if v = strings.TrimSpace(v); v != "" && set.valid(v) {
+            changes.add(set.m, v)
+        }
+    }
+    changes.commit()
+    set.mu.Unlock()
+    changes.notify()
+    return set
+}
+
+// AddReturn adds one or multiple items to the set, and returns the items that are newly added,
+// which means they did not exist in the set before. The returned items keep the order of <item>.
+//
+// 添加元素项到集合中(支持多个), 并按照传入顺序返回新添加的(之前不存在的)元素项.
+func (set *Set) AddReturn(item...interface{}) []interface{} {
+    if !set.writable() {
+        return nil
+    }
+    added := make([]interface{}, 0)
+    set.mu.Lock()
+    set.own()
+    changes := set.changes()
+    for _, v := range item {
+        if _, ok := set.m[v]; !ok && set.valid(v) {
+            changes.add(set.m, v)
+            added = append(added, v)
+        }
+    }
+    changes.commit()
+    set.mu.Unlock()
+    changes.notify()
+    return added
+}
+
+// AddIfNotExist adds <item> to the set if it does not exist in the set,
+// and returns true if the item is added, or else false.
+// The checking and adding are done within one write lock, which is atomic.
+//
+// 当元素项不存在时将其添加到集合中并返回true, 否则返回false(检查与添加为原子操作).
+func (set *Set) AddIfNotExist(item interface{}) bool {
+    if !set.writable() {
+        return false
+    }
+    set.mu.Lock()
+    set.own()
+    changes := set.changes()
+    _, ok   := set.m[item]
+    added   := !ok && set.valid(item)
+    if added {
+        changes.add(set.m, item)
+    }
+    changes.commit()
+    set.mu.Unlock()
+    changes.notify()
+    return added
+}
+
+// AddIfNotExistFunc adds <item> to the set if it does not exist in the set and
+// function <f> returns true, and returns true if the item is added, or else false.
+// Note that <f> is called within the write lock, so it must not call any method of the set,
+// or else it will be deadlocked.
+//
+// 当元素项不存在且f返回true时将其添加到集合中并返回true, 否则返回false.
+// 注意f在写锁内执行, 因此f中不能调用当前集合的任何方法, 否则会造成死锁.
+func (set *Set) AddIfNotExistFunc(item interface{}, f func() bool) bool {
+    if !set.writable() {
+        return false
+    }
+    var changes setChanges
+    defer changes.notify()
+    set.mu.Lock()
+    defer set.mu.Unlock()
+    set.own()
+    changes = set.changes()
+    defer changes.commit()
+    if _, ok := set.m[item]; !ok && set.valid(item) && f() {
+        changes.add(set.m, item)
         return true
     }
+    return false
+}
+
+// Check whether the set contains <item>.
+//
+// 键是否存在.
+func (set *Set) Contains(item interface{}) bool {
+    set.mu.RLock()
+    _, exists := set.m[item]
+    set.mu.RUnlock()
+    return exists
+}
+
+// Get returns the item stored in the set which equals to <item>, and whether it exists.
+// It's useful for interning, as the stored item is returned instead of the given one.
+// Note that Go maps do not expose their stored keys, so it scans the items linearly
+// when <item> exists, which costs O(n).
+//
+// 获得集合中与item相等的已存储元素项, 以及该元素项是否存在. 由于返回的是已存储的元素项, 可用于字符串驻留等场景.
+// 注意由于map不提供获取已存储键名的方法, 当item存在时需要线性遍历元素项, 时间复杂度为O(n).
+func (set *Set) Get(item interface{}) (interface{}, bool) {
     set.mu.RLock()
     defer set.mu.RUnlock()
-    other.mu.RLock()
-    defer other.mu.RUnlock()
-    if len(set.m) != len(other.m) {
-        return false
+    if _, ok := set.m[item]; !ok {
+        return nil, false
     }
-    for key := range set.m {
-        if _, ok := other.m[key]; !ok {
-            return false
+    for k := range set.m {
+        if k == item {
+            return k, true
         }
     }
-    return true
+    return nil, false
 }
 
-// Check whether the current set is sub-set of <other>.
+// ContainsAll checks whether the set contains all of the given <items>.
+// It returns true if <items> is empty.
 //
-// 判断当前集合是否为other集合的子集.
-func (set *Set) IsSubsetOf(other *Set) bool {
-    if set == other {
-        return true
-    }
+// 判断集合是否包含所有给定的元素项, items为空时返回true.
+func (set *Set) ContainsAll(items...interface{}) bool {
     set.mu.RLock()
     defer set.mu.RUnlock()
-    other.mu.RLock()
-    defer other.mu.RUnlock()
-    for key := range set.m {
-        if _, ok := other.m[key]; !ok {
+    for _, v := range items {
+        if _, ok := set.m[v]; !ok {
             return false
         }
     }
     return true
 }
 
-// Returns a new set which is the union of <set> and <other>.
-// Which means, all the items in <newSet> is in <set> or in <other>.
+// ContainsAny checks whether the set contains any of the given <items>.
+// It returns false if <items> is empty.
 //
-// 并集, 返回新的集合：属于set或属于others的元素为元素的集合.
-func (set *Set) Union(others ... *Set) (newSet *Set) {
-    newSet = NewSet(true)
+// 判断集合是否包含给定元素项中的任意一个, items为空时返回false.
+func (set *Set) ContainsAny(items...interface{}) bool {
     set.mu.RLock()
     defer set.mu.RUnlock()
-    for _, other := range others {
-        if set != other {
-            other.mu.RLock()
-        }
-        for k, v := range set.m {
-            newSet.m[k] = v
-        }
-        if set != other {
-            for k, v := range other.m {
-                newSet.m[k] = v
-            }
-        }
-        if set != other {
-            other.mu.RUnlock()
+    for _, v := range items {
+        if _, ok := set.m[v]; ok {
+            return true
         }
     }
-
-    return
+    return false
 }
 
-// Returns a new set which is the difference set from <set> to <other>.
-// Which means, all the items in <newSet> is in <set> and not in <other>.
+// ContainsWhich returns the given <items> which are in the set, in the order of <items>.
 //
-// 差集, 返回新的集合: 属于set且不属于others的元素为元素的集合.
-func (set *Set) Diff(others...*Set) (newSet *Set) {
-    newSet = NewSet(true)
+// 返回给定元素项中存在于集合中的元素项(保持items的顺序).
+func (set *Set) ContainsWhich(items...interface{}) []interface{} {
     set.mu.RLock()
     defer set.mu.RUnlock()
-    for _, other := range others {
-        if set == other {
-            continue
-        }
-        other.mu.RLock()
-        for k, v := range set.m {
-            if _, ok := other.m[k]; !ok {
-                newSet.m[k] = v
-            }
+    ret := make([]interface{}, 0, len(items))
+    for _, v := range items {
+        if _, ok := set.m[v]; ok {
+            ret = append(ret, v)
         }
-        other.mu.RUnlock()
     }
-    return
+    return ret
 }
 
-// Returns a new set which is the intersection from <set> to <other>.
-// Which means, all the items in <newSet> is in <set> and also in <other>.
+// ContainsI checks whether the set contains <item> case-insensitively,
+// the <item> and the items of the set are compared in their string forms using strings.EqualFold.
+// Note that it scans the items linearly if <item> is not exactly in the set, which costs O(n).
 //
-// 交集, 返回新的集合: 属于set且属于others的元素为元素的集合.
-func (set *Set) Intersect(others...*Set) (newSet *Set) {
-    newSet = NewSet(true)
+// 不区分大小写判断集合是否包含item(按照字符串形式使用strings.EqualFold比较).
+// 注意当item不是精确存在于集合中时需要线性遍历元素项, 时间复杂度为O(n).
+func (set *Set) ContainsI(item interface{}) bool {
     set.mu.RLock()
     defer set.mu.RUnlock()
-    for _, other := range others {
-        if set != other {
-            other.mu.RLock()
-        }
-        for k, v := range set.m {
-            if _, ok := other.m[k]; ok {
-                newSet.m[k] = v
-            }
-        }
-        if set != other {
-            other.mu.RUnlock()
+    if _, ok := set.m[item]; ok {
+        return true
+    }
+    s := gconv.String(item)
+    for k := range set.m {
+        if strings.EqualFold(gconv.String(k), s) {
+            return true
         }
     }
-    return
+    return false
+}
+
+// Remove one or multiple items from the set.
+// It does nothing for the items that are not in the set.
+//
+// 从集合中删除元素项(支持多个).
+func (set *Set) Remove(item...interface{}) *Set {
+    if !set.writable() {
+        return set
+    }
+    set.mu.Lock()
+    set.own()
+    changes := set.changes()
+    for _, v := range item {
+        changes.remove(set.m, v)
+    }
+    changes.commit()
+    set.mu.Unlock()
+    changes.notify()
+    return set
+}
+
+// RemoveIf removes all the items for which callback function <f> returns true.
+// Note that <f> is called within the write lock, so it must not call any method of the set.
+//
+// 删除回调函数f返回true的所有元素项. 注意f在写锁内执行, 因此f中不能调用当前集合的任何方法.
+func (set *Set) RemoveIf(f func(v interface{}) bool) *Set {
+    if !set.writable() {
+        return set
+    }
+    var changes setChanges
+    defer changes.notify()
+    set.mu.Lock()
+    defer set.mu.Unlock()
+    set.own()
+    changes = set.changes()
+    defer changes.commit()
+    for k := range set.m {
+        if f(k) {
+            changes.remove(set.m, k)
+        }
+    }
+    return set
+}
+
+// OnChange registers callback function <f>, which is called after an item is actually
+// added to the set, with <added> being the item and <removed> being nil,
+// or after an item is actually removed from the set, with <added> being nil
+// and <removed> being the item. It covers all the methods modifying the items of the set,
+// like Add, AddSlice, Remove, RemoveIf, Pop, Clear, Flush, Replace, Walk, Merge, Retain,
+// MoveTo (for both of the sets) and the decoding methods, except LockFunc and LockFuncResult,
+// whose changes to the map are not tracked. Multiple callback functions can be registered,
+// and they are called outside of the lock, so they can safely call the methods of the set.
+//
+// 注册元素项变更回调函数f, 当元素项被真正添加到集合后调用f(item, nil),
+// 当元素项被真正从集合中删除后调用f(nil, item). 适用于所有修改集合元素项的方法,
+// 例如Add/AddSlice/Remove/RemoveIf/Pop/Clear/Flush/Replace/Walk/Merge/Retain/MoveTo(两个集合均会触发)以及各解码方法,
+// 但LockFunc及LockFuncResult中对map的修改不会被追踪.
+// 支持注册多个回调函数, 回调函数在锁外执行, 因此可以安全地调用当前集合的方法.
+func (set *Set) OnChange(f func(added, removed interface{})) *Set {
+    set.mu.Lock()
+    set.listeners = append(set.listeners[:len(set.listeners):len(set.listeners)], f)
+    set.mu.Unlock()
+    return set
+}
+
+// setChanges records the items actually added to and removed from the set within the write lock,
+// which are passed to the callback functions registered by OnChange by notify after the lock is released.
+// It records nothing if no callback function is registered. It also evaluates the size watchers
+// registered by OnSizeExceed by commit, so that every modification of the set may fire them.
+//
+// 在写锁内记录真正添加到集合及从集合中删除的元素项, 释放锁后通过notify传递给OnChange注册的回调函数.
+// 没有注册回调函数时不做任何记录. 同时通过commit检查OnSizeExceed注册的集合大小阈值, 使得集合的任何修改都可能触发阈值回调.
+type setChanges struct {
+    set       *Set
+    listeners []func(added, removed interface{})
+    added     []interface{}
+    removed   []interface{}
+    fired     []func(size int) // 需要触发的集合大小阈值回调函数
+    size      int              // 修改完成后的集合大小
+}
+
+// changes returns the recorder of the changes of the set, it should be called within the write lock.
+//
+// 获得集合元素项变更的记录器, 需要在写锁内调用.
+func (set *Set) changes() setChanges {
+    return setChanges{set : set, listeners : set.listeners}
+}
+
+// commit evaluates the size watchers with the size of the set after the modification,
+// it should be called within the write lock when the modification is done.
+//
+// 使用修改完成后的集合大小检查集合大小阈值, 需要在修改完成后在写锁内调用.
+func (c *setChanges) commit() {
+    if c.set == nil {
+        return
+    }
+    c.size = len(c.set.m)
+    for _, w := range c.set.watchers {
+        if w.rearm && c.size <= w.threshold {
+            w.fired = false
+        }
+        if !w.fired && c.size > w.threshold {
+            w.fired = true
+            c.fired = append(c.fired, w.f)
+        }
+    }
+}
+
+// add adds <item> to <m>, and records it if it's not in <m> before.
+//
+// 添加元素项到m中, 当元素项之前不存在时进行记录.
+func (c *setChanges) add(m map[interface{}]struct{}, item interface{}) {
+    if len(c.listeners) > 0 {
+        if _, ok := m[item]; !ok {
+            c.added = append(c.added, item)
+        }
+    }
+    m[item] = struct{}{}
+}
+
+// remove deletes <item> from <m>, and records it if it's in <m> before.
+//
+// 从m中删除元素项, 当元素项之前存在时进行记录.
+func (c *setChanges) remove(m map[interface{}]struct{}, item interface{}) {
+    if len(c.listeners) > 0 {
+        if _, ok := m[item]; ok {
+            c.removed = append(c.removed, item)
+        }
+    }
+    delete(m, item)
+}
+
+// replace records the differences between <from> and <to>, when the map <from> of the set is replaced with <to>.
+//
+// 当集合的map由from替换为to时, 记录两者之间的差异.
+func (c *setChanges) replace(from, to map[interface{}]struct{}) {
+    if len(c.listeners) == 0 {
+        return
+    }
+    for k := range from {
+        if _, ok := to[k]; !ok {
+            c.removed = append(c.removed, k)
+        }
+    }
+    for k := range to {
+        if _, ok := from[k]; !ok {
+            c.added = append(c.added, k)
+        }
+    }
+}
+
+// notify calls the callback functions with the recorded changes, it should be called after the lock is released.
+//
+// 使用记录的变更调用回调函数, 需要在释放锁后调用.
+func (c *setChanges) notify() {
+    for _, v := range c.removed {
+        for _, f := range c.listeners {
+            f(nil, v)
+        }
+    }
+    for _, v := range c.added {
+        for _, f := range c.listeners {
+            f(v, nil)
+        }
+    }
+    for _, f := range c.fired {
+        f(c.size)
+    }
+}
+
+// OnSizeExceed registers callback function <f>, which is called with the new size of the set
+// after a modification pushes the size of the set above <threshold>, no matter it's done by Add,
+// AddSlice, Merge, Replace, MoveTo, the decoding methods or any other method modifying the items,
+// except LockFunc and LockFuncResult. It's called outside of the lock.
+//
+// By default <f> is called only once, no matter how many times the size crosses <threshold>
+// afterwards. If <rearm> is given true, it's re-armed when the size of the set falls back to
+// <threshold> or below, so that <f> is called again on every crossing.
+//
+// 注册集合大小阈值回调函数f, 当修改操作(Add/AddSlice/Merge/Replace/MoveTo/各解码方法等除LockFunc及LockFuncResult外的任何修改方法)
+// 使得集合大小超过threshold后, 在锁外以集合的新大小调用f.
+// 默认情况下f只会被调用一次; 当rearm为true时, 集合大小回落到threshold及以内后会重新启用,
+// 即每次超过阈值时都会调用f.
+func (set *Set) OnSizeExceed(threshold int, f func(size int), rearm...bool) *Set {
+    w := &sizeWatcher{
+        threshold : threshold,
+        f         : f,
+    }
+    if len(rearm) > 0 {
+        w.rearm = rearm[0]
+    }
+    set.mu.Lock()
+    set.watchers = append(set.watchers, w)
+    set.mu.Unlock()
+    return set
+}
+
+// SetValidator sets the validator function <f> of the set, which is called within the write lock
+// before an item is put into the set by any of its methods, like Add, AddSlice, AddString, AddReturn,
+// AddIfNotExist, Replace, Walk, Merge, AddAll, MoveTo (of the destination set) and the decoding methods,
+// the items for which <f> returns a non-nil error are skipped. Use AddSafe to get the validation error.
+// The only exceptions are LockFunc and LockFuncResult, which modify the map directly.
+// The items already in the set are not validated again.
+// A nil <f> removes the validator. Note that <f> must not call any method of the set.
+//
+// 设置集合的元素项校验函数f, 在通过任意方法(例如Add/AddSlice/AddString/AddReturn/AddIfNotExist/Replace/Walk/Merge/AddAll/
+// MoveTo(目标集合)以及各解码方法)将元素项放入集合前在写锁内调用, f返回非nil错误的元素项会被忽略, 使用AddSafe可以获得校验错误.
+// 唯一的例外是直接修改map的LockFunc及LockFuncResult. 集合中已存在的元素项不会被重新校验.
+// f为nil时表示移除校验函数. 注意f中不能调用当前集合的任何方法.
+func (set *Set) SetValidator(f func(v interface{}) error) *Set {
+    set.mu.Lock()
+    set.validator = f
+    set.mu.Unlock()
+    return set
+}
+
+// valid checks whether <item> passes the validator of the set, it should be called within the lock.
+//
+// 判断元素项是否通过集合的校验函数校验, 需要在锁内调用.
+func (set *Set) valid(item interface{}) bool {
+    return set.validator == nil || set.validator(item) == nil
+}
+
+// Freeze makes the set permanently immutable. After freezing, the methods modifying the items
+// of the set, like Add, Remove, Clear, Merge and the decoding methods, panic in default,
+// or do nothing if <silent> is given true. The reading methods are still allowed.
+// Unlike Readonly, it protects the set itself, but note that the map passed to the callback
+// of LockFunc is not protected.
+//
+// 永久冻结集合使其不可修改. 冻结后, 修改集合元素项的方法(例如Add/Remove/Clear/Merge以及各解码方法)默认会panic,
+// silent为true时则不做任何操作. 读取方法仍然可用. 与Readonly不同的是, 冻结保护的是集合本身,
+// 但注意LockFunc回调函数中传入的map不受保护.
+func (set *Set) Freeze(silent...bool) *Set {
+    frozen := int32(gFROZEN_PANIC)
+    if len(silent) > 0 && silent[0] {
+        frozen = gFROZEN_SILENT
+    }
+    atomic.StoreInt32(&set.frozen, frozen)
+    return set
+}
+
+// IsFrozen checks whether the set is frozen by Freeze.
+//
+// 判断集合是否已被冻结.
+func (set *Set) IsFrozen() bool {
+    return atomic.LoadInt32(&set.frozen) != gFROZEN_NONE
+}
+
+// writable checks whether the items of the set can be modified,
+// it panics if the set is frozen in default mode, or returns false if it's frozen silently.
+// It should be called before acquiring the lock, so that the panic does not leave the lock held.
+//
+// 判断集合元素项是否可以修改, 集合以默认方式冻结时panic, 以silent方式冻结时返回false.
+// 需要在加锁前调用, 避免panic时锁未被释放.
+func (set *Set) writable() bool {
+    switch atomic.LoadInt32(&set.frozen) {
+        case gFROZEN_PANIC:
+            panic("gset: cannot modify a frozen set")
+        case gFROZEN_SILENT:
+            return false
+    }
+    return true
+}
+
+// Pop randomly removes and returns an item from the set.
+// It returns nil if the set is empty.
+//
+// 随机从集合中移除并返回一个元素项, 集合为空时返回nil.
+func (set *Set) Pop() interface{} {
+    if !set.writable() {
+        return nil
+    }
+    var item interface{}
+    set.mu.Lock()
+    set.own()
+    changes := set.changes()
+    for k := range set.m {
+        changes.remove(set.m, k)
+        item = k
+        break
+    }
+    changes.commit()
+    set.mu.Unlock()
+    changes.notify()
+    return item
+}
+
+// Pops randomly removes and returns <size> items from the set.
+// It returns all items if <size> is negative or greater than the size of the set.
+//
+// 随机从集合中移除并返回size个元素项, size为负数或者大于集合大小时返回所有元素项.
+func (set *Set) Pops(size int) []interface{} {
+    if !set.writable() {
+        return nil
+    }
+    set.mu.Lock()
+    set.own()
+    changes := set.changes()
+    if size < 0 || size > len(set.m) {
+        size = len(set.m)
+    }
+    index := 0
+    array := make([]interface{}, size)
+    for k := range set.m {
+        if index == size {
+            break
+        }
+        changes.remove(set.m, k)
+        array[index] = k
+        index++
+    }
+    changes.commit()
+    set.mu.Unlock()
+    changes.notify()
+    return array
+}
+
+// Rand randomly returns an item from the set without removing it.
+// It returns nil if the set is empty.
+//
+// 从集合中随机获取一个元素项(不移除), 集合为空时返回nil.
+func (set *Set) Rand() interface{} {
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    if len(set.m) == 0 {
+        return nil
+    }
+    index := grand.Intn(len(set.m))
+    for k := range set.m {
+        if index == 0 {
+            return k
+        }
+        index--
+    }
+    return nil
+}
+
+// Rands randomly returns <size> distinct items from the set without removing them.
+// It returns all items in random order if <size> is greater than the size of the set.
+//
+// 从集合中随机获取size个不重复的元素项(不移除), size大于集合大小时以随机顺序返回所有元素项.
+func (set *Set) Rands(size int) []interface{} {
+    array := set.Slice()
+    if size < 0 {
+        size = 0
+    }
+    if size > len(array) {
+        size = len(array)
+    }
+    n := make([]interface{}, size)
+    for i, v := range grand.Perm(len(array)) {
+        if i == size {
+            break
+        }
+        n[i] = array[v]
+    }
+    return n
+}
+
+// Any returns an arbitrary item from the set without removing it, and true.
+// It returns nil and false if the set is empty.
+//
+// 从集合中获取任意一个元素项(不移除)并返回true, 集合为空时返回nil及false.
+func (set *Set) Any() (interface{}, bool) {
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    for k := range set.m {
+        return k, true
+    }
+    return nil, false
+}
+
+// Get size of the set.
+//
+// 获得集合大小。
+func (set *Set) Size() int {
+    set.mu.RLock()
+    l := len(set.m)
+    set.mu.RUnlock()
+    return l
+}
+
+// Count returns the count of items for which callback function <f> returns true.
+//
+// 获得回调函数f返回true的元素项数量.
+func (set *Set) Count(f func(v interface{}) bool) (count int) {
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    for k := range set.m {
+        if f(k) {
+            count++
+        }
+    }
+    return
+}
+
+// Clear the set.
+//
+// 清空集合。
+func (set *Set) Clear() *Set {
+    if !set.writable() {
+        return set
+    }
+    set.mu.Lock()
+    changes := set.changes()
+    m       := make(map[interface{}]struct{})
+    changes.replace(set.m, m)
+    set.m      = m
+    set.shared = false
+    changes.commit()
+    set.mu.Unlock()
+    changes.notify()
+    return set
+}
+
+// Flush clears the set and returns its items as slice atomically,
+// so no item is lost or returned twice under concurrent adding.
+//
+// 清空集合并返回清空前的元素项列表(原子操作), 并发添加时不会丢失或者重复返回元素项.
+func (set *Set) Flush() []interface{} {
+    if !set.writable() {
+        return nil
+    }
+    set.mu.Lock()
+    changes := set.changes()
+    i       := 0
+    ret     := make([]interface{}, len(set.m))
+    for item := range set.m {
+        ret[i] = item
+        i++
+    }
+    m := make(map[interface{}]struct{})
+    changes.replace(set.m, m)
+    set.m      = m
+    set.shared = false
+    changes.commit()
+    set.mu.Unlock()
+    changes.notify()
+    return ret
+}
+
+// Replace replaces all the items of the set with <item> atomically,
+// so the concurrent readers see either the old items or the new items, never a mix of them.
+//
+// 使用item原子地替换集合的所有元素项, 并发读取时只会看到替换前或者替换后的完整元素项, 不会看到中间状态.
+func (set *Set) Replace(item...interface{}) *Set {
+    if !set.writable() {
+        return set
+    }
+    set.mu.Lock()
+    changes := set.changes()
+    m       := make(map[interface{}]struct{}, len(item))
+    for _, v := range item {
+        if set.valid(v) {
+            m[v] = struct{}{}
+        }
+    }
+    changes.replace(set.m, m)
+    set.m      = m
+    set.shared = false
+    changes.commit()
+    set.mu.Unlock()
+    changes.notify()
+    return set
+}
+
+// Compact rebuilds the underlying map of the set with exactly the size of its items,
+// which releases the excess memory held by the map after large amount of deletions,
+// as the go map never shrinks its storage.
+//
+// 按照当前元素项数量重建集合的底层map, 用于在大量删除操作后释放多余的内存(go的map不会自动收缩存储空间).
+func (set *Set) Compact() *Set {
+    set.mu.Lock()
+    m := make(map[interface{}]struct{}, len(set.m))
+    for k, v := range set.m {
+        m[k] = v
+    }
+    set.m      = m
+    set.shared = false
+    set.mu.Unlock()
+    return set
+}
+
+// Clone returns a new concurrent-safe set with a copy of the items of current set,
+// no matter whether the current set is concurrent-safe or not.
+//
+// 复制当前集合并返回新的并发安全集合(无论当前集合是否为并发安全).
+func (set *Set) Clone() *Set {
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    newSet := NewSet()
+    newSet.m = make(map[interface{}]struct{}, len(set.m))
+    for k, v := range set.m {
+        newSet.m[k] = v
+    }
+    return newSet
+}
+
+// CloneCOW returns a new concurrent-safe set which shares the items with current set in copy-on-write way,
+// which costs O(1). The first modification on either of the sets copies the shared items,
+// so it's suitable for taking frequent snapshots of large sets with rare writes.
+//
+// 以写时复制的方式复制当前集合并返回新的并发安全集合(时间复杂度为O(1)), 两个集合共享元素项,
+// 任一集合第一次修改时才会复制共享的元素项, 适用于写少读多的大集合频繁创建快照的场景.
+func (set *Set) CloneCOW() *Set {
+    set.mu.Lock()
+    defer set.mu.Unlock()
+    set.shared    = true
+    newSet       := NewSet()
+    newSet.m      = set.m
+    newSet.shared = true
+    return newSet
+}
+
+// own copies the underlying map of the set if it's shared with other sets by CloneCOW,
+// which should be called within the write lock before modifying the map in place.
+// Note that the shared map is never modified, so the last set sharing it copies it too.
+//
+// 当集合底层map通过CloneCOW与其他集合共享时复制该map, 需要在写锁内原地修改map之前调用.
+// 注意共享的map永远不会被修改, 因此最后一个共享该map的集合也会进行复制.
+func (set *Set) own() {
+    if !set.shared {
+        return
+    }
+    m := make(map[interface{}]struct{}, len(set.m))
+    for k, v := range set.m {
+        m[k] = v
+    }
+    set.m      = m
+    set.shared = false
+}
+
+// DeepClone returns a new concurrent-safe set with deep copies of the items of current set,
+// so that the changes to the nested pointers of the items do not affect the original set.
+// The items are copied using the optional function <copier>, or else by gob round-trip,
+// in which case the items must be gob-encodable, or else an error is returned.
+// Note that it's much slower than Clone, which copies the items by assignment.
+//
+// Unlike Clone, it returns an error besides the new set: the gob round-trip fails for
+// the items of unregistered concrete types, channels, functions and so on, and returning
+// a partially copied set or panicking in that case would both surprise the caller.
+//
+// 复制当前集合的元素项(深拷贝)并返回新的并发安全集合, 对元素项内嵌指针的修改不会影响原集合.
+// 元素项使用可选的复制函数copier进行复制, 否则使用gob编解码进行复制, 此时元素项必须可以被gob编码, 否则返回错误.
+// 注意其性能远低于使用赋值复制元素项的Clone.
+// 与Clone不同的是该方法同时返回错误: 未注册的具体类型/chan/func等元素项无法通过gob编解码复制,
+// 此时返回部分复制的集合或者panic都会出乎调用方的意料.
+func (set *Set) DeepClone(copier...func(v interface{}) interface{}) (*Set, error) {
+    copyFunc := deepCopyByGob
+    if len(copier) > 0 && copier[0] != nil {
+        copyFunc = func(v interface{}) (interface{}, error) {
+            return copier[0](v), nil
+        }
+    }
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    newSet := NewSize(len(set.m))
+    for k := range set.m {
+        v, err := copyFunc(k)
+        if err != nil {
+            return nil, err
+        }
+        newSet.m[v] = struct{}{}
+    }
+    return newSet, nil
+}
+
+// deepCopyByGob deep copies <v> by gob round-trip.
+//
+// 使用gob编解码对v进行深拷贝.
+func deepCopyByGob(v interface{}) (interface{}, error) {
+    if v == nil {
+        return nil, nil
+    }
+    buffer := bytes.NewBuffer(nil)
+    if err := gob.NewEncoder(buffer).Encode(v); err != nil {
+        return nil, err
+    }
+    ptr := reflect.New(reflect.TypeOf(v))
+    if err := gob.NewDecoder(buffer).DecodeValue(ptr); err != nil {
+        return nil, err
+    }
+    return ptr.Elem().Interface(), nil
+}
+
+// Get the copy of items from set as slice.
+//
+// 获得集合元素项列表.
+func (set *Set) Slice() []interface{} {
+    set.mu.RLock()
+    i   := 0
+    ret := make([]interface{}, len(set.m))
+    for item := range set.m {
+        ret[i] = item
+        i++
+    }
+    set.mu.RUnlock()
+    return ret
+}
+
+// SortedSlice returns a copy of items from set as slice, which is sorted by custom function <less>.
+// If <less> is nil, the items are compared in their string forms.
+//
+// 获得使用自定义排序函数less排序后的集合元素项列表, less为nil时按照元素项的字符串形式排序.
+func (set *Set) SortedSlice(less func(v1, v2 interface{}) bool) []interface{} {
+    array := set.Slice()
+    if less == nil {
+        less = func(v1, v2 interface{}) bool {
+            return gconv.String(v1) < gconv.String(v2)
+        }
+    }
+    sort.Slice(array, func(i, j int) bool {
+        return less(array[i], array[j])
+    })
+    return array
+}
+
+// Ints returns the items of the set as []int, the items are converted using gconv.Int.
+//
+// 获得集合元素项的[]int列表(元素项使用gconv.Int转换).
+func (set *Set) Ints() []int {
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    i   := 0
+    ret := make([]int, len(set.m))
+    for k := range set.m {
+        ret[i] = gconv.Int(k)
+        i++
+    }
+    return ret
+}
+
+// Strings returns the items of the set as []string, the items are converted using gconv.String.
+//
+// 获得集合元素项的[]string列表(元素项使用gconv.String转换).
+func (set *Set) Strings() []string {
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    i   := 0
+    ret := make([]string, len(set.m))
+    for k := range set.m {
+        ret[i] = gconv.String(k)
+        i++
+    }
+    return ret
+}
+
+// BoolMap returns a copy of the items of the set as map[interface{}]bool, all values of which are true.
+// It's useful for the code which uses map[interface{}]bool as a lookup table.
+// Note that the method name Map is used by the transform method.
+//
+// 获得集合元素项的map[interface{}]bool副本(键值均为true), 便于与使用map[interface{}]bool作为查找表的代码交互.
+// 注意Map方法名已被元素项转换方法使用.
+func (set *Set) BoolMap() map[interface{}]bool {
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    m := make(map[interface{}]bool, len(set.m))
+    for k := range set.m {
+        m[k] = true
+    }
+    return m
+}
+
+// SortedInts returns the items of the set as []int sorted in ascending order,
+// the items are converted using gconv.Int.
+//
+// 获得集合元素项按照升序排序的[]int列表(元素项使用gconv.Int转换).
+func (set *Set) SortedInts() []int {
+    array := set.Ints()
+    sort.Ints(array)
+    return array
+}
+
+// SortedStrings returns the items of the set as []string sorted in ascending order,
+// the items are converted using gconv.String.
+//
+// 获得集合元素项按照升序排序的[]string列表(元素项使用gconv.String转换).
+func (set *Set) SortedStrings() []string {
+    array := set.Strings()
+    sort.Strings(array)
+    return array
+}
+
+// Calculate the sum of items in the set.
+//
+// 对集合中的元素项求和(将元素值转换为int类型后叠加)。
+func (set *Set) Sum() (sum int) {
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    for k, _ := range set.m {
+        sum += gconv.Int(k)
+    }
+    return
+}
+
+// Reduce accumulates the items of the set into a single value starting with <initial>,
+// by calling <f> with the accumulated value and each item.
+// As the iteration order of the set is random, <f> should be commutative and associative
+// to produce stable result.
+//
+// 从initial开始, 使用回调函数f将集合元素项依次累积为单个值.
+// 由于集合的遍历顺序是随机的, f应当满足交换律及结合律才能得到稳定的结果.
+func (set *Set) Reduce(initial interface{}, f func(acc, v interface{}) interface{}) interface{} {
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    acc := initial
+    for k := range set.m {
+        acc = f(acc, k)
+    }
+    return acc
+}
+
+// Min returns the minimum item of the set using custom function <less>,
+// if <less> is nil, the items are compared as float64 numbers.
+// It returns nil if the set is empty.
+//
+// 使用自定义比较函数less获得集合中的最小元素项(less为nil时按照float64数值比较), 集合为空时返回nil.
+func (set *Set) Min(less func(v1, v2 interface{}) bool) (min interface{}) {
+    if less == nil {
+        less = lessFloat64
+    }
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    first := true
+    for k := range set.m {
+        if first || less(k, min) {
+            min   = k
+            first = false
+        }
+    }
+    return
+}
+
+// Max returns the maximum item of the set using custom function <less>,
+// if <less> is nil, the items are compared as float64 numbers.
+// It returns nil if the set is empty.
+//
+// 使用自定义比较函数less获得集合中的最大元素项(less为nil时按照float64数值比较), 集合为空时返回nil.
+func (set *Set) Max(less func(v1, v2 interface{}) bool) (max interface{}) {
+    if less == nil {
+        less = lessFloat64
+    }
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    first := true
+    for k := range set.m {
+        if first || less(max, k) {
+            max   = k
+            first = false
+        }
+    }
+    return
+}
+
+// lessFloat64 is the default comparison function of Min and Max,
+// which compares the items as float64 numbers.
+//
+// Min及Max的默认比较函数, 按照float64数值比较元素项.
+func lessFloat64(v1, v2 interface{}) bool {
+    return gconv.Float64(v1) < gconv.Float64(v2)
+}
+
+// Join set items with a string.
+//
+// 使用glue字符串串连当前集合的元素项，构造成新的字符串返回。
+func (set *Set) Join(glue string) string {
+    return strings.Join(gconv.Strings(set.Slice()), glue)
+}
+
+// JoinSorted joins set items with a string <glue>, the items are sorted in their string forms
+// before joining, which produces deterministic result.
+//
+// 使用glue字符串串连当前集合的元素项(按照元素项字符串形式排序后串连)，构造成新的字符串返回，结果是确定的。
+func (set *Set) JoinSorted(glue string) string {
+    return strings.Join(set.SortedStrings(), glue)
+}
+
+// WriteLines writes the items of the set in their string forms to <w>, one item per line,
+// through an internal buffer. It returns the count of bytes written to <w> and any error encountered.
+//
+// 将集合元素项的字符串形式逐行写入w(内部使用缓冲), 返回写入w的字节数及写入时产生的错误.
+func (set *Set) WriteLines(w io.Writer) (int, error) {
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    n      := 0
+    writer := bufio.NewWriter(w)
+    for k := range set.m {
+        c, err := writer.WriteString(gconv.String(k) + "\n")
+        n += c
+        if err != nil {
+            return n - writer.Buffered(), err
+        }
+    }
+    if err := writer.Flush(); err != nil {
+        return n - writer.Buffered(), err
+    }
+    return n, nil
+}
+
+// Return set items as a string, which are joined by char ','.
+//
+// 使用glue字符串串连当前集合的元素项，构造成新的字符串返回。
+func (set *Set) String() string {
+    return set.Join(",")
+}
+
+// Lock writing by callback function f.
+//
+// 使用自定义方法执行加锁修改操作。
+func (set *Set) LockFunc(f func(m map[interface{}]struct{})) *Set {
+    set.mu.Lock(true)
+    defer set.mu.Unlock(true)
+    set.own()
+    f(set.m)
+    return set
+}
+
+// Lock writing by callback function f, and return the result of f.
+//
+// 使用自定义方法执行加锁修改操作, 并返回自定义方法的执行结果。
+func (set *Set) LockFuncResult(f func(m map[interface{}]struct{}) interface{}) interface{} {
+    set.mu.Lock(true)
+    defer set.mu.Unlock(true)
+    set.own()
+    return f(set.m)
+}
+
+// Lock reading by callback function f.
+//
+// 使用自定义方法执行加锁读取操作。
+func (set *Set) RLockFunc(f func(m map[interface{}]struct{})) *Set {
+    set.mu.RLock(true)
+    defer set.mu.RUnlock(true)
+    f(set.m)
+    return set
+}
+
+// lockPair locks <mu> for writing if <write> is true or else for reading, and locks <other> for reading,
+// in the order of their addresses, which avoids the deadlock when two sets are operated on each other
+// concurrently, eg: a.Equal(b) and b.Equal(a). It returns the function to unlock both of them.
+//
+// 按照地址顺序对mu(write为true时加写锁, 否则加读锁)和other(加读锁)加锁, 避免两个集合并发地相互操作
+// (例如a.Equal(b)与b.Equal(a))时产生死锁. 返回值为同时解锁两者的函数.
+func lockPair(mu, other *rwmutex.RWMutex, write bool) (unlock func()) {
+    lock, release := mu.RLock, mu.RUnlock
+    if write {
+        lock, release = mu.Lock, mu.Unlock
+    }
+    if mu == other {
+        lock()
+        return func() { release() }
+    }
+    if reflect.ValueOf(mu).Pointer() < reflect.ValueOf(other).Pointer() {
+        lock()
+        other.RLock()
+    } else {
+        other.RLock()
+        lock()
+    }
+    return func() {
+        other.RUnlock()
+        release()
+    }
+}
+
+// lockBoth locks both <mu> and <other> for writing in the order of their addresses like lockPair.
+// It returns the function to unlock both of them.
+//
+// 按照地址顺序对mu和other加写锁(同lockPair), 返回值为同时解锁两者的函数.
+func lockBoth(mu, other *rwmutex.RWMutex) (unlock func()) {
+    if mu == other {
+        mu.Lock()
+        return func() { mu.Unlock() }
+    }
+    first, second := mu, other
+    if reflect.ValueOf(other).Pointer() < reflect.ValueOf(mu).Pointer() {
+        first, second = other, mu
+    }
+    first.Lock()
+    second.Lock()
+    return func() {
+        second.Unlock()
+        first.Unlock()
+    }
+}
+
+// rlockAll locks all the given <mus> for reading in the order of their addresses like lockPair,
+// the duplicated ones are locked only once. It returns the function to unlock all of them.
+//
+// 按照地址顺序对所有mus加读锁(同lockPair), 重复的锁只加一次. 返回值为解锁所有锁的函数.
+func rlockAll(mus []*rwmutex.RWMutex) (unlock func()) {
+    sorted := make([]*rwmutex.RWMutex, 0, len(mus))
+    for _, mu := range mus {
+        exists := false
+        for _, v := range sorted {
+            if v == mu {
+                exists = true
+                break
+            }
+        }
+        if !exists {
+            sorted = append(sorted, mu)
+        }
+    }
+    sort.Slice(sorted, func(i, j int) bool {
+        return reflect.ValueOf(sorted[i]).Pointer() < reflect.ValueOf(sorted[j]).Pointer()
+    })
+    for _, mu := range sorted {
+        mu.RLock()
+    }
+    return func() {
+        for i := len(sorted) - 1; i >= 0; i-- {
+            sorted[i].RUnlock()
+        }
+    }
+}
+
+// Check whether the two sets equal.
+//
+// 判断两个集合是否相等.
+func (set *Set) Equal(other *Set) bool {
+    if set == other {
+        return true
+    }
+    defer lockPair(set.mu, other.mu, false)()
+    if len(set.m) != len(other.m) {
+        return false
+    }
+    for key := range set.m {
+        if _, ok := other.m[key]; !ok {
+            return false
+        }
+    }
+    return true
+}
+
+// EqualFunc checks whether the two sets equal after applying function <normalize> to each item.
+//
+// 判断两个集合在使用normalize处理每个元素项后是否相等.
+func (set *Set) EqualFunc(other *Set, normalize func(v interface{}) interface{}) bool {
+    set.mu.RLock()
+    m1 := make(map[interface{}]struct{}, len(set.m))
+    for k := range set.m {
+        m1[normalize(k)] = struct{}{}
+    }
+    set.mu.RUnlock()
+    other.mu.RLock()
+    m2 := make(map[interface{}]struct{}, len(other.m))
+    for k := range other.m {
+        m2[normalize(k)] = struct{}{}
+    }
+    other.mu.RUnlock()
+    if len(m1) != len(m2) {
+        return false
+    }
+    for key := range m1 {
+        if _, ok := m2[key]; !ok {
+            return false
+        }
+    }
+    return true
+}
+
+// EqualSlice checks whether the set contains exactly the distinct items of slice <items>.
+//
+// 判断集合是否与slice中的不重复元素项完全相同.
+func (set *Set) EqualSlice(items []interface{}) bool {
+    lookup := make(map[interface{}]struct{}, len(items))
+    for _, v := range items {
+        lookup[v] = struct{}{}
+    }
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    if len(set.m) != len(lookup) {
+        return false
+    }
+    for key := range lookup {
+        if _, ok := set.m[key]; !ok {
+            return false
+        }
+    }
+    return true
+}
+
+// Check whether the current set is sub-set of <other>.
+//
+// 判断当前集合是否为other集合的子集.
+func (set *Set) IsSubsetOf(other *Set) bool {
+    if set == other {
+        return true
+    }
+    defer lockPair(set.mu, other.mu, false)()
+    for key := range set.m {
+        if _, ok := other.m[key]; !ok {
+            return false
+        }
+    }
+    return true
+}
+
+// Check whether the current set is proper sub-set of <other>,
+// which means it's sub-set of <other> and has fewer items than <other>.
+//
+// 判断当前集合是否为other集合的真子集.
+func (set *Set) IsProperSubsetOf(other *Set) bool {
+    if set == other {
+        return false
+    }
+    defer lockPair(set.mu, other.mu, false)()
+    if len(set.m) >= len(other.m) {
+        return false
+    }
+    for key := range set.m {
+        if _, ok := other.m[key]; !ok {
+            return false
+        }
+    }
+    return true
+}
+
+// Check whether the current set is super-set of <other>.
+//
+// 判断当前集合是否为other集合的超集.
+func (set *Set) IsSupersetOf(other *Set) bool {
+    return other.IsSubsetOf(set)
+}
+
+// Check whether the current set and <other> have no item in common.
+//
+// 判断当前集合与other集合是否没有交集.
+func (set *Set) IsDisjoint(other *Set) bool {
+    defer lockPair(set.mu, other.mu, false)()
+    small, large := set.m, other.m
+    if len(small) > len(large) {
+        small, large = large, small
+    }
+    for key := range small {
+        if _, ok := large[key]; ok {
+            return false
+        }
+    }
+    return true
+}
+
+// Returns a new set which is the union of <set> and <other>.
+// Which means, all the items in <newSet> is in <set> or in <other>.
+// Each of the sets is read-locked only once, and the empty ones are not iterated.
+//
+// 并集, 返回新的集合：属于set或属于others的元素为元素的集合. 每个集合只加一次读锁, 空集合不会被遍历.
+func (set *Set) Union(others ... *Set) (newSet *Set) {
+    newSet = NewSet(!set.mu.IsSafe())
+    set.mu.RLock()
+    for k, v := range set.m {
+        newSet.m[k] = v
+    }
+    set.mu.RUnlock()
+    for _, other := range others {
+        if set == other {
+            continue
+        }
+        other.mu.RLock()
+        for k, v := range other.m {
+            newSet.m[k] = v
+        }
+        other.mu.RUnlock()
+    }
+    return
+}
+
+// UnionSize returns the size of the union of <set> and <other>, without creating the union set.
+//
+// 获得set与other并集的大小(不创建并集集合).
+func (set *Set) UnionSize(other *Set) int {
+    defer lockPair(set.mu, other.mu, false)()
+    if set == other {
+        return len(set.m)
+    }
+    return len(set.m) + len(other.m) - intersectSize(set.m, other.m)
+}
+
+// UnionSizeOf returns the count of distinct items of all the given <sets>, without creating the union set.
+// Each set is read-locked one by one, so it's not an atomic snapshot of all the sets.
+//
+// 获得所有sets中不重复元素项的数量(不创建并集集合). 各集合是逐个加读锁读取的, 因此并不是所有集合的原子快照.
+func UnionSizeOf(sets...*Set) int {
+    if len(sets) == 0 {
+        return 0
+    }
+    if len(sets) == 1 {
+        return sets[0].Size()
+    }
+    keys := make(map[interface{}]struct{})
+    for _, set := range sets {
+        set.mu.RLock()
+        for k := range set.m {
+            keys[k] = struct{}{}
+        }
+        set.mu.RUnlock()
+    }
+    return len(keys)
+}
+
+// IntersectSize returns the size of the intersection of <set> and <other>,
+// without creating the intersection set.
+//
+// 获得set与other交集的大小(不创建交集集合).
+func (set *Set) IntersectSize(other *Set) int {
+    defer lockPair(set.mu, other.mu, false)()
+    if set == other {
+        return len(set.m)
+    }
+    return intersectSize(set.m, other.m)
+}
+
+// intersectSize counts the keys in common of <m1> and <m2>,
+// by iterating the smaller one and probing the larger one.
+//
+// 计算m1与m2共有键名的数量(遍历较小的map并在较大的map中查找).
+func intersectSize(m1, m2 map[interface{}]struct{}) int {
+    if len(m1) > len(m2) {
+        m1, m2 = m2, m1
+    }
+    size := 0
+    for k := range m1 {
+        if _, ok := m2[k]; ok {
+            size++
+        }
+    }
+    return size
+}
+
+// Returns a new set which is the difference set from <set> to <others>.
+// Which means, all the items in <newSet> is in <set> and not in any of <others>, the same as DiffSlices.
+//
+// 差集, 返回新的集合: 属于set且不属于任何others的元素为元素的集合, 与DiffSlices相同.
+func (set *Set) Diff(others...*Set) (newSet *Set) {
+    newSet = NewSet(!set.mu.IsSafe())
+    for _, other := range others {
+        // 集合与自身的差集为空集合.
+        if set == other {
+            return
+        }
+    }
+    set.mu.RLock()
+    for k, v := range set.m {
+        newSet.m[k] = v
+    }
+    set.mu.RUnlock()
+    // 每次只对一个集合加锁, 无需考虑多个集合间的加锁顺序.
+    for _, other := range others {
+        if len(newSet.m) == 0 {
+            break
+        }
+        other.mu.RLock()
+        if len(other.m) < len(newSet.m) {
+            for k := range other.m {
+                delete(newSet.m, k)
+            }
+        } else {
+            for k := range newSet.m {
+                if _, ok := other.m[k]; ok {
+                    delete(newSet.m, k)
+                }
+            }
+        }
+        other.mu.RUnlock()
+    }
+    return
+}
+
+// Returns a new set which is the difference set from <set> to slice <items>.
+// Which means, all the items in <newSet> is in <set> and not in <items>.
+//
+// 差集, 返回新的集合: 属于set且不属于items的元素为元素的集合.
+func (set *Set) DiffSlice(items []interface{}) (newSet *Set) {
+    return set.DiffSlices(items)
+}
+
+// Returns a new set which is the difference set from <set> to all the given slices.
+// Which means, all the items in <newSet> is in <set> and not in any of <slices>, the same as Diff.
+//
+// 差集, 返回新的集合: 属于set且不属于任何slices的元素为元素的集合, 与Diff相同.
+func (set *Set) DiffSlices(slices...[]interface{}) (newSet *Set) {
+    newSet = NewSet(!set.mu.IsSafe())
+    size   := 0
+    for _, items := range slices {
+        size += len(items)
+    }
+    lookup := make(map[interface{}]struct{}, size)
+    for _, items := range slices {
+        for _, v := range items {
+            lookup[v] = struct{}{}
+        }
+    }
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    for k, v := range set.m {
+        if _, ok := lookup[k]; !ok {
+            newSet.m[k] = v
+        }
+    }
+    return
+}
+
+// Returns a new set which is the intersection from <set> to <others>.
+// Which means, all the items in <newSet> is in <set> and also in all of <others>.
+// It iterates the smallest one of the sets and probes the rest, which minimizes the comparisons,
+// so it iterates nothing if any of the sets is empty. Each set is read-locked only once.
+//
+// 交集, 返回新的集合: 属于set且属于所有others的元素为元素的集合.
+// 遍历元素项最少的集合并在其余集合中查找, 以减少比较次数, 因此任一集合为空时不会遍历任何元素项. 每个集合只加一次读锁.
+func (set *Set) Intersect(others...*Set) (newSet *Set) {
+    newSet = NewSet(!set.mu.IsSafe())
+    if len(others) == 0 {
+        return
+    }
+    sets := append([]*Set{set}, others...)
+    mus  := make([]*rwmutex.RWMutex, len(sets))
+    for i, s := range sets {
+        mus[i] = s.mu
+    }
+    defer rlockAll(mus)()
+    smallest := set
+    for _, other := range others {
+        if len(other.m) < len(smallest.m) {
+            smallest = other
+        }
+    }
+    for k, v := range smallest.m {
+        found := true
+        for _, s := range sets {
+            if s == smallest {
+                continue
+            }
+            if _, ok := s.m[k]; !ok {
+                found = false
+                break
+            }
+        }
+        if found {
+            newSet.m[k] = v
+        }
+    }
+    return
+}
+
+// IntersectFunc returns a new set which is the intersection from <set> to the implicit set
+// defined by function <contains>, eg: all the even numbers.
+// Which means, all the items in <newSet> is in <set> and <contains> returns true for them.
+//
+// 交集, 返回新的集合: 属于set且属于由contains定义的隐式集合(例如所有偶数)的元素为元素的集合.
+func (set *Set) IntersectFunc(contains func(v interface{}) bool) (newSet *Set) {
+    newSet = NewSet(!set.mu.IsSafe())
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    for k, v := range set.m {
+        if contains(k) {
+            newSet.m[k] = v
+        }
+    }
+    return
 }
 
 // Returns a new set which is the complement from <set> to <full>.
@@ -277,17 +1930,458 @@ func (set *Set) Intersect(others...*Set) (newSet *Set) {
 // 补集, 返回新的集合: (前提: set应当为full的子集)属于全集full不属于集合set的元素组成的集合.
 // 如果给定的full集合不是set的全集时，返回full与set的差集.
 func (set *Set) Complement(full *Set) (newSet *Set) {
-    newSet = NewSet(true)
-    set.mu.RLock()
-    defer set.mu.RUnlock()
-    if set != full {
-        full.mu.RLock()
-        defer full.mu.RUnlock()
-    }
+    newSet = NewSet(!set.mu.IsSafe())
+    defer lockPair(set.mu, full.mu, false)()
     for k, v := range full.m {
         if _, ok := set.m[k]; !ok {
             newSet.m[k] = v
         }
     }
     return
+}
+
+// MarshalJSON implements the interface MarshalJSON for json.Marshal,
+// which encodes the set as a JSON array of its items.
+//
+// 实现json.Marshal接口，将集合编码为JSON数组(空集合编码为[]).
+func (set *Set) MarshalJSON() ([]byte, error) {
+    if set == nil {
+        return []byte("[]"), nil
+    }
+    return json.Marshal(set.Slice())
+}
+
+// MarshalJSONSorted is like MarshalJSON, but the items are sorted by their string forms
+// before being encoded, which makes the output stable, eg: for golden-file tests.
+//
+// 同MarshalJSON, 但元素项会先按照字符串形式排序再进行编码, 因此输出结果是稳定的(例如用于测试数据比较).
+func (set *Set) MarshalJSONSorted() ([]byte, error) {
+    if set == nil {
+        return []byte("[]"), nil
+    }
+    return json.Marshal(set.SortedSlice(nil))
+}
+
+// UnmarshalJSON implements the interface UnmarshalJSON for json.Unmarshal,
+// which clears the set and fills it with the items of given JSON array <b>.
+// The integral numbers are decoded as int items and the other numbers as float64 items,
+// so that a set of ints survives the JSON round-trip.
+// A JSON null leaves the set empty, and any other non-array input returns an error.
+//
+// 实现json.Unmarshal接口，清空集合并使用给定JSON数组的元素项填充集合.
+// 整数解码为int类型元素项, 其他数字解码为float64类型元素项, 因此int集合可以通过JSON编解码还原.
+func (set *Set) UnmarshalJSON(b []byte) error {
+    if !set.writable() {
+        return nil
+    }
+    if !json.Valid(b) {
+        // 非法JSON时使用json.Unmarshal获得具体的语法错误.
+        return json.Unmarshal(b, new([]interface{}))
+    }
+    var items []interface{}
+    decoder := json.NewDecoder(bytes.NewReader(b))
+    decoder.UseNumber()
+    if err := decoder.Decode(&items); err != nil {
+        return err
+    }
+    for i, v := range items {
+        if n, ok := v.(json.Number); ok {
+            if integer, err := n.Int64(); err == nil {
+                items[i] = int(integer)
+            } else {
+                items[i], _ = n.Float64()
+            }
+        }
+    }
+    if set.mu == nil {
+        set.mu = rwmutex.New()
+    }
+    set.mu.Lock()
+    changes := set.changes()
+    m       := make(map[interface{}]struct{}, len(items))
+    for _, v := range items {
+        if set.valid(v) {
+            m[v] = struct{}{}
+        }
+    }
+    changes.replace(set.m, m)
+    set.m      = m
+    set.shared = false
+    changes.commit()
+    set.mu.Unlock()
+    changes.notify()
+    return nil
+}
+
+// Returns a new set which is the symmetric difference of <set> and <other>.
+// Which means, all the items in <newSet> is in <set> or in <other>, but not in both.
+//
+// 对称差集, 返回新的集合: 只属于set或者只属于other的元素为元素的集合.
+func (set *Set) SymmetricDifference(other *Set) (newSet *Set) {
+    newSet = NewSet(!set.mu.IsSafe())
+    if set == other {
+        return
+    }
+    defer lockPair(set.mu, other.mu, false)()
+    for k, v := range set.m {
+        if _, ok := other.m[k]; !ok {
+            newSet.m[k] = v
+        }
+    }
+    for k, v := range other.m {
+        if _, ok := set.m[k]; !ok {
+            newSet.m[k] = v
+        }
+    }
+    return
+}
+
+// Delta returns the changes from <set> to <other> in one call,
+// <added> contains the items in <other> but not in <set>,
+// and <removed> contains the items in <set> but not in <other>.
+//
+// 获得从set到other的变化: added为属于other且不属于set的元素项集合, removed为属于set且不属于other的元素项集合.
+func (set *Set) Delta(other *Set) (added, removed *Set) {
+    added   = NewSet(!set.mu.IsSafe())
+    removed = NewSet(!set.mu.IsSafe())
+    if set == other {
+        return
+    }
+    defer lockPair(set.mu, other.mu, false)()
+    for k, v := range set.m {
+        if _, ok := other.m[k]; !ok {
+            removed.m[k] = v
+        }
+    }
+    for k, v := range other.m {
+        if _, ok := set.m[k]; !ok {
+            added.m[k] = v
+        }
+    }
+    return
+}
+
+// DiffStats returns the counts of the items only in <set>, only in <other> and in both of them,
+// without creating any result set. The counts are consistent, which means
+// onlyInSet + common equals the size of <set>, and onlyInOther + common equals the size of <other>.
+//
+// 获得只属于set, 只属于other以及同时属于两者的元素项数量(不创建任何结果集合).
+// 各数量是一致的, 即onlyInSet + common等于set的大小, onlyInOther + common等于other的大小.
+func (set *Set) DiffStats(other *Set) (onlyInSet, onlyInOther, common int) {
+    defer lockPair(set.mu, other.mu, false)()
+    if set == other {
+        return 0, 0, len(set.m)
+    }
+    for k := range set.m {
+        if _, ok := other.m[k]; ok {
+            common++
+        }
+    }
+    return len(set.m) - common, len(other.m) - common, common
+}
+
+// Merge adds all the items of <others> into the current set, without creating a new set.
+//
+// 合并others集合中的所有元素项到当前集合中(不创建新集合).
+func (set *Set) Merge(others...*Set) *Set {
+    if !set.writable() {
+        return set
+    }
+    for _, other := range others {
+        if set == other {
+            continue
+        }
+        unlock  := lockPair(set.mu, other.mu, true)
+        set.own()
+        changes := set.changes()
+        for k := range other.m {
+            if set.valid(k) {
+                changes.add(set.m, k)
+            }
+        }
+        changes.commit()
+        unlock()
+        changes.notify()
+    }
+    return set
+}
+
+// AddAll adds all the items of <other> into the current set, without creating a new set.
+//
+// 添加other集合中的所有元素项到当前集合中(不创建新集合).
+func (set *Set) AddAll(other *Set) *Set {
+    if !set.writable() {
+        return set
+    }
+    if set == other {
+        return set
+    }
+    unlock  := lockPair(set.mu, other.mu, true)
+    set.own()
+    changes := set.changes()
+    for k := range other.m {
+        if set.valid(k) {
+            changes.add(set.m, k)
+        }
+    }
+    changes.commit()
+    unlock()
+    changes.notify()
+    return set
+}
+
+// Retain removes the items of current set that are not in <other>,
+// which is the in-place version of Intersect.
+//
+// 删除当前集合中不属于other集合的元素项(原地求交集).
+func (set *Set) Retain(other *Set) *Set {
+    if !set.writable() {
+        return set
+    }
+    if set == other {
+        return set
+    }
+    unlock  := lockPair(set.mu, other.mu, true)
+    set.own()
+    changes := set.changes()
+    for k := range set.m {
+        if _, ok := other.m[k]; !ok {
+            changes.remove(set.m, k)
+        }
+    }
+    changes.commit()
+    unlock()
+    changes.notify()
+    return set
+}
+
+// MoveTo moves the given <items> from the current set to <dst> atomically,
+// the items not in the current set or failing the validator of <dst> are skipped and kept in the current set.
+// It moves all the items if <items> is empty.
+//
+// 原子地将给定元素项从当前集合移动到dst集合, 不存在于当前集合中或者未通过dst校验函数校验的元素项会被忽略(保留在当前集合中).
+// items为空时移动所有元素项.
+func (set *Set) MoveTo(dst *Set, items...interface{}) *Set {
+    if set == dst {
+        return set
+    }
+    if !set.writable() || !dst.writable() {
+        return set
+    }
+    unlock     := lockBoth(set.mu, dst.mu)
+    set.own()
+    dst.own()
+    srcChanges := set.changes()
+    dstChanges := dst.changes()
+    if len(items) == 0 {
+        for k := range set.m {
+            if dst.valid(k) {
+                dstChanges.add(dst.m, k)
+                srcChanges.remove(set.m, k)
+            }
+        }
+        if len(set.m) == 0 {
+            // 释放删除元素项后map占用的多余内存.
+            set.m = make(map[interface{}]struct{})
+        }
+    } else {
+        for _, k := range items {
+            if _, ok := set.m[k]; ok && dst.valid(k) {
+                dstChanges.add(dst.m, k)
+                srcChanges.remove(set.m, k)
+            }
+        }
+    }
+    srcChanges.commit()
+    dstChanges.commit()
+    unlock()
+    srcChanges.notify()
+    dstChanges.notify()
+    return set
+}
+
+// Similarity returns the Jaccard similarity of <set> and <other>,
+// which is the size of their intersection divided by the size of their union.
+// It returns 0 if both sets are empty.
+//
+// 返回两个集合的Jaccard相似度(交集大小/并集大小), 两个集合都为空时返回0.
+func (set *Set) Similarity(other *Set) float64 {
+    defer lockPair(set.mu, other.mu, false)()
+    intersection := 0
+    for k := range set.m {
+        if _, ok := other.m[k]; ok {
+            intersection++
+        }
+    }
+    union := len(set.m) + len(other.m) - intersection
+    if union == 0 {
+        return 0
+    }
+    return float64(intersection) / float64(union)
+}
+
+// Value implements the interface driver.Valuer for database/sql,
+// which stores the set as a JSON array string, so that the items containing char ','
+// and the non-string items survive the round-trip through Scan.
+//
+// 实现database/sql的driver.Valuer接口, 将集合以JSON数组字符串形式存储,
+// 使得包含','的元素项及非字符串元素项可以通过Scan还原.
+func (set *Set) Value() (driver.Value, error) {
+    if set == nil {
+        return nil, nil
+    }
+    b, err := set.MarshalJSON()
+    if err != nil {
+        return nil, err
+    }
+    return string(b), nil
+}
+
+// Scan implements the interface sql.Scanner for database/sql,
+// which clears the set and fills it with the items parsed from <value>.
+// The <value> is decoded as a JSON array if it starts with char '[',
+// or else it is split by char ',' into string items.
+//
+// 实现database/sql的sql.Scanner接口, 清空集合并使用从value解析的元素项填充集合.
+// value以'['开头时按照JSON数组解析, 否则按照','分隔为字符串元素项.
+func (set *Set) Scan(value interface{}) error {
+    if !set.writable() {
+        return nil
+    }
+    s := ""
+    switch v := value.(type) {
+        case nil:
+        case []byte: s = string(v)
+        case string: s = v
+        default:     s = gconv.String(v)
+    }
+    s = strings.TrimSpace(s)
+    if len(s) > 0 && s[0] == '[' {
+        return set.UnmarshalJSON([]byte(s))
+    }
+    if set.mu == nil {
+        set.mu = rwmutex.New()
+    }
+    set.mu.Lock()
+    changes := set.changes()
+    m       := make(map[interface{}]struct{})
+    for _, v := range strings.Split(s, ",") {
+        if v != "" && set.valid(v) {
+            m[v] = struct{}{}
+        }
+    }
+    changes.replace(set.m, m)
+    set.m      = m
+    set.shared = false
+    changes.commit()
+    set.mu.Unlock()
+    changes.notify()
+    return nil
+}
+
+// GobEncode implements the interface gob.GobEncoder, which encodes the items of the set.
+// Note that the concrete types of the items other than the basic types should be
+// registered using gob.Register before encoding.
+//
+// 实现gob.GobEncoder接口, 对集合的元素项进行编码(非基本类型的元素项需要预先使用gob.Register注册).
+func (set *Set) GobEncode() ([]byte, error) {
+    buffer := bytes.NewBuffer(nil)
+    if err := gob.NewEncoder(buffer).Encode(set.Slice()); err != nil {
+        return nil, err
+    }
+    return buffer.Bytes(), nil
+}
+
+// GobDecode implements the interface gob.GobDecoder,
+// which replaces the items of the set with the decoded items.
+//
+// 实现gob.GobDecoder接口, 使用解码后的元素项替换集合的元素项.
+func (set *Set) GobDecode(b []byte) error {
+    if !set.writable() {
+        return nil
+    }
+    var items []interface{}
+    if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&items); err != nil {
+        return err
+    }
+    if set.mu == nil {
+        set.mu = rwmutex.New()
+    }
+    set.mu.Lock()
+    changes := set.changes()
+    m       := make(map[interface{}]struct{}, len(items))
+    for _, v := range items {
+        if set.valid(v) {
+            m[v] = struct{}{}
+        }
+    }
+    changes.replace(set.m, m)
+    set.m      = m
+    set.shared = false
+    changes.commit()
+    set.mu.Unlock()
+    changes.notify()
+    return nil
+}
+
+// Hash returns an order-independent hash of the items of the set,
+// which is the XOR of the FNV-1a hashes of the items in string form.
+// Sets with the same items always produce the same hash. It's for equality bucketing
+// like cache keys, not for cryptographic usage.
+//
+// 返回与元素项顺序无关的集合哈希值(元素项字符串形式的FNV-1a哈希值的异或), 相同元素项的集合哈希值相同.
+// 仅用于缓存键等相等性分组场景, 不可用于加密用途.
+func (set *Set) Hash() uint64 {
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    hash := uint64(0)
+    for k := range set.m {
+        h := fnv.New64a()
+        h.Write([]byte(gconv.String(k)))
+        hash ^= h.Sum64()
+    }
+    return hash
+}
+
+// MarshalYAML implements the interface Marshaler of gopkg.in/yaml.v2,
+// which encodes the set as a YAML sequence of its items.
+//
+// 实现yaml.v2的Marshaler接口, 将集合编码为YAML序列(空集合编码为[]).
+func (set *Set) MarshalYAML() (interface{}, error) {
+    if set == nil {
+        return []interface{}{}, nil
+    }
+    return set.Slice(), nil
+}
+
+// UnmarshalYAML implements the interface Unmarshaler of gopkg.in/yaml.v2,
+// which clears the set and fills it with the items of the YAML sequence.
+//
+// 实现yaml.v2的Unmarshaler接口, 清空集合并使用YAML序列的元素项填充集合.
+func (set *Set) UnmarshalYAML(unmarshal func(interface{}) error) error {
+    if !set.writable() {
+        return nil
+    }
+    var items []interface{}
+    if err := unmarshal(&items); err != nil {
+        return err
+    }
+    if set.mu == nil {
+        set.mu = rwmutex.New()
+    }
+    set.mu.Lock()
+    changes := set.changes()
+    m       := make(map[interface{}]struct{}, len(items))
+    for _, v := range items {
+        if set.valid(v) {
+            m[v] = struct{}{}
+        }
+    }
+    changes.replace(set.m, m)
+    set.m      = m
+    set.shared = false
+    changes.commit()
+    set.mu.Unlock()
+    changes.notify()
+    return nil
 }
\ No newline at end of file
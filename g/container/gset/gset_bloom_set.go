@@ -0,0 +1,110 @@
+// Copyright 2019 gf Author(https://github.com/gogf/gf). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gset
+
+import (
+    "github.com/gogf/gf/g/internal/rwmutex"
+)
+
+const (
+    // BloomSet默认位数
+    gDEFAULT_BLOOM_SIZE   = 1 << 20
+    // BloomSet默认哈希函数数量
+    gDEFAULT_BLOOM_HASHES = 7
+)
+
+// BloomSet is a probabilistic set based on Bloom filter, which tests the membership of items
+// using a fixed amount of memory. It has no false negative, but may have false positive,
+// so MayContain returning false means the item is definitely absent, and true means it's possibly present.
+// It does not store the items, so it does not support Remove or Slice by design.
+// Items are hashed with their types, so 1 and "1" are different items.
+//
+// 基于布隆过滤器的概率集合, 使用固定大小的内存判断元素项是否存在. 不会漏判但可能误判,
+// 即MayContain返回false表示元素项一定不存在, 返回true表示元素项可能存在.
+// 由于不存储元素项, 因此不支持Remove/Slice等操作. 元素项连同其类型计算哈希值, 因此1和"1"是不同的元素项.
+type BloomSet struct {
+    mu     *rwmutex.RWMutex
+    bits   []uint64 // 位数组
+    size   uint64   // 位数
+    hashes uint64   // 哈希函数数量
+}
+
+// Create a bloom set with <size> bits and <hashes> hash functions,
+// <size> and <hashes> use gDEFAULT_BLOOM_SIZE and gDEFAULT_BLOOM_HASHES if they're less than 1.
+// The param <unsafe> used to specify whether using array with un-concurrent-safety,
+// which is false in default, means concurrent-safe in default.
+//
+// 创建一个位数为size, 哈希函数数量为hashes的布隆集合(小于1时使用默认值)，
+// 参数unsafe用于指定是否用于非并发安全场景，默认为false，表示并发安全。
+func NewBloomSet(size int, hashes int, unsafe...bool) *BloomSet {
+    if size < 1 {
+        size = gDEFAULT_BLOOM_SIZE
+    }
+    if hashes < 1 {
+        hashes = gDEFAULT_BLOOM_HASHES
+    }
+    return &BloomSet{
+        mu     : rwmutex.New(unsafe...),
+        bits   : make([]uint64, (size + 63)/64),
+        size   : uint64(size),
+        hashes : uint64(hashes),
+    }
+}
+
+// locations returns the bit locations of <item>, which are computed by double hashing.
+//
+// 获得元素项对应的位位置列表(使用双重哈希计算).
+func (set *BloomSet) locations(item interface{}) []uint64 {
+    h   := hashItem(item)
+    h1  := h & 0xffffffff
+    h2  := h >> 32 | 1
+    ret := make([]uint64, set.hashes)
+    for i := uint64(0); i < set.hashes; i++ {
+        ret[i] = (h1 + i*h2) % set.size
+    }
+    return ret
+}
+
+// Add one or multiple items to the set.
+//
+// 添加元素项到集合中(支持多个).
+func (set *BloomSet) Add(item...interface{}) *BloomSet {
+    set.mu.Lock()
+    for _, v := range item {
+        for _, l := range set.locations(v) {
+            set.bits[l/64] |= 1 << (l%64)
+        }
+    }
+    set.mu.Unlock()
+    return set
+}
+
+// MayContain checks whether <item> is possibly in the set,
+// it returns false if <item> is definitely not in the set.
+//
+// 判断元素项是否可能存在于集合中, 返回false表示元素项一定不存在.
+func (set *BloomSet) MayContain(item interface{}) bool {
+    locations := set.locations(item)
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    for _, l := range locations {
+        if set.bits[l/64] & (1 << (l%64)) == 0 {
+            return false
+        }
+    }
+    return true
+}
+
+// Clear the set.
+//
+// 清空集合。
+func (set *BloomSet) Clear() *BloomSet {
+    set.mu.Lock()
+    set.bits = make([]uint64, len(set.bits))
+    set.mu.Unlock()
+    return set
+}
@@ -0,0 +1,244 @@
+// Copyright 2019 gf Author(https://github.com/gogf/gf). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+//go:build go1.18
+// +build go1.18
+
+package gset
+
+import (
+    "github.com/gogf/gf/g/internal/rwmutex"
+)
+
+// GSet is a set of items of type T, which is backed by map[T]struct{}.
+// It provides compile-time type safety for homogeneous sets, and is only available for go1.18+.
+//
+// 基于泛型的集合, 元素项类型为T, 底层使用map[T]struct{}存储(需要go1.18及以上版本).
+type GSet[T comparable] struct {
+    mu *rwmutex.RWMutex
+    m  map[T]struct{}
+}
+
+// Create a set, which contains un-repeated items.
+// The param <unsafe> used to specify whether using array with un-concurrent-safety,
+// which is false in default, means concurrent-safe in default.
+//
+// 创建一个空的集合对象，参数unsafe用于指定是否用于非并发安全场景，默认为false，表示并发安全。
+func NewGSet[T comparable](unsafe...bool) *GSet[T] {
+    return &GSet[T]{
+        m  : make(map[T]struct{}),
+        mu : rwmutex.New(unsafe...),
+    }
+}
+
+// Iterate the set by given callback <f>,
+// if <f> returns true then continue iterating; or false to stop.
+//
+// 给定回调函数对原始内容进行遍历，回调函数返回true表示继续遍历，否则停止遍历。
+func (set *GSet[T]) Iterator(f func (v T) bool) *GSet[T] {
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    for k, _ := range set.m {
+        if !f(k) {
+            break
+        }
+    }
+    return set
+}
+
+// Add one or multiple items to the set.
+//
+// 添加元素项到集合中(支持多个).
+func (set *GSet[T]) Add(item...T) *GSet[T] {
+    set.mu.Lock()
+    for _, v := range item {
+        set.m[v] = struct{}{}
+    }
+    set.mu.Unlock()
+    return set
+}
+
+// Check whether the set contains <item>.
+//
+// 键是否存在.
+func (set *GSet[T]) Contains(item T) bool {
+    set.mu.RLock()
+    _, exists := set.m[item]
+    set.mu.RUnlock()
+    return exists
+}
+
+// Remove one or multiple items from the set.
+// It does nothing for the items that are not in the set.
+//
+// 从集合中删除元素项(支持多个).
+func (set *GSet[T]) Remove(item...T) *GSet[T] {
+    set.mu.Lock()
+    for _, v := range item {
+        delete(set.m, v)
+    }
+    set.mu.Unlock()
+    return set
+}
+
+// Get size of the set.
+//
+// 获得集合大小。
+func (set *GSet[T]) Size() int {
+    set.mu.RLock()
+    l := len(set.m)
+    set.mu.RUnlock()
+    return l
+}
+
+// Clear the set.
+//
+// 清空集合。
+func (set *GSet[T]) Clear() *GSet[T] {
+    set.mu.Lock()
+    set.m = make(map[T]struct{})
+    set.mu.Unlock()
+    return set
+}
+
+// Get the copy of items from set as slice.
+//
+// 获得集合元素项列表.
+func (set *GSet[T]) Slice() []T {
+    set.mu.RLock()
+    i   := 0
+    ret := make([]T, len(set.m))
+    for item := range set.m {
+        ret[i] = item
+        i++
+    }
+    set.mu.RUnlock()
+    return ret
+}
+
+// Check whether the two sets equal.
+//
+// 判断两个集合是否相等.
+func (set *GSet[T]) Equal(other *GSet[T]) bool {
+    if set == other {
+        return true
+    }
+    defer lockPair(set.mu, other.mu, false)()
+    if len(set.m) != len(other.m) {
+        return false
+    }
+    for key := range set.m {
+        if _, ok := other.m[key]; !ok {
+            return false
+        }
+    }
+    return true
+}
+
+// Returns a new set which is the union of <set> and <other>.
+// Which means, all the items in <newSet> is in <set> or in <other>.
+//
+// 并集, 返回新的集合：属于set或属于others的元素为元素的集合.
+func (set *GSet[T]) Union(others ... *GSet[T]) (newSet *GSet[T]) {
+    newSet = NewGSet[T](!set.mu.IsSafe())
+    set.mu.RLock()
+    for k, v := range set.m {
+        newSet.m[k] = v
+    }
+    set.mu.RUnlock()
+    for _, other := range others {
+        if set == other {
+            continue
+        }
+        other.mu.RLock()
+        for k, v := range other.m {
+            newSet.m[k] = v
+        }
+        other.mu.RUnlock()
+    }
+    return
+}
+
+// Returns a new set which is the difference set from <set> to <others>.
+// Which means, all the items in <newSet> is in <set> and not in any of <others>.
+//
+// 差集, 返回新的集合: 属于set且不属于任何others的元素为元素的集合.
+func (set *GSet[T]) Diff(others...*GSet[T]) (newSet *GSet[T]) {
+    newSet = NewGSet[T](!set.mu.IsSafe())
+    for _, other := range others {
+        // 集合与自身的差集为空集合.
+        if set == other {
+            return
+        }
+    }
+    set.mu.RLock()
+    for k, v := range set.m {
+        newSet.m[k] = v
+    }
+    set.mu.RUnlock()
+    // 每次只对一个集合加锁, 无需考虑多个集合间的加锁顺序.
+    for _, other := range others {
+        if len(newSet.m) == 0 {
+            break
+        }
+        other.mu.RLock()
+        if len(other.m) < len(newSet.m) {
+            for k := range other.m {
+                delete(newSet.m, k)
+            }
+        } else {
+            for k := range newSet.m {
+                if _, ok := other.m[k]; ok {
+                    delete(newSet.m, k)
+                }
+            }
+        }
+        other.mu.RUnlock()
+    }
+    return
+}
+
+// Returns a new set which is the intersection from <set> to <others>.
+// Which means, all the items in <newSet> is in <set> and also in all of <others>.
+// It iterates the smallest one of the sets and probes the rest, which minimizes the comparisons,
+// so it iterates nothing if any of the sets is empty. Each set is read-locked only once.
+//
+// 交集, 返回新的集合: 属于set且属于所有others的元素为元素的集合.
+// 遍历元素项最少的集合并在其余集合中查找, 以减少比较次数, 因此任一集合为空时不会遍历任何元素项. 每个集合只加一次读锁.
+func (set *GSet[T]) Intersect(others...*GSet[T]) (newSet *GSet[T]) {
+    newSet = NewGSet[T](!set.mu.IsSafe())
+    if len(others) == 0 {
+        return
+    }
+    sets := append([]*GSet[T]{set}, others...)
+    mus  := make([]*rwmutex.RWMutex, len(sets))
+    for i, s := range sets {
+        mus[i] = s.mu
+    }
+    defer rlockAll(mus)()
+    smallest := set
+    for _, other := range others {
+        if len(other.m) < len(smallest.m) {
+            smallest = other
+        }
+    }
+    for k, v := range smallest.m {
+        found := true
+        for _, s := range sets {
+            if s == smallest {
+                continue
+            }
+            if _, ok := s.m[k]; !ok {
+                found = false
+                break
+            }
+        }
+        if found {
+            newSet.m[k] = v
+        }
+    }
+    return
+}
@@ -0,0 +1,137 @@
+// Copyright 2019 gf Author(https://github.com/gogf/gf). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gset
+
+import (
+    "github.com/gogf/gf/g/internal/rwmutex"
+)
+
+// HashedSet is a set whose items are identified by the keys computed by a custom hash function,
+// the items with the same key are considered equal, eg: the structs with the same ID field.
+//
+// 使用自定义哈希函数计算的键名来识别元素项的集合, 键名相同的元素项视为相同(例如ID字段相同的结构体).
+type HashedSet struct {
+    mu   *rwmutex.RWMutex
+    m    map[interface{}]interface{}  // 键名 => 元素项
+    hash func(interface{}) interface{} // 元素项键名计算函数
+}
+
+// Create a hashed set, whose items are identified by the keys computed by <hash>,
+// the keys must be comparable.
+// The param <unsafe> used to specify whether using array with un-concurrent-safety,
+// which is false in default, means concurrent-safe in default.
+//
+// 创建一个空的集合对象, 元素项使用hash计算的键名进行识别(键名必须为可比较类型),
+// 参数unsafe用于指定是否用于非并发安全场景，默认为false，表示并发安全。
+func NewWithHasher(hash func(interface{}) interface{}, unsafe...bool) *HashedSet {
+    return &HashedSet{
+        m    : make(map[interface{}]interface{}),
+        mu   : rwmutex.New(unsafe...),
+        hash : hash,
+    }
+}
+
+// Iterate the set by given callback <f>,
+// if <f> returns true then continue iterating; or false to stop.
+//
+// 给定回调函数对原始内容进行遍历，回调函数返回true表示继续遍历，否则停止遍历。
+func (set *HashedSet) Iterator(f func (v interface{}) bool) *HashedSet {
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    for _, v := range set.m {
+        if !f(v) {
+            break
+        }
+    }
+    return set
+}
+
+// Add one or multiple items to the set.
+// The item whose key already exists in the set is ignored, so the first added one is kept.
+//
+// 添加元素项到集合中(支持多个), 键名已存在的元素项会被忽略, 即保留先添加的元素项.
+func (set *HashedSet) Add(item...interface{}) *HashedSet {
+    set.mu.Lock()
+    for _, v := range item {
+        key := set.hash(v)
+        if _, ok := set.m[key]; !ok {
+            set.m[key] = v
+        }
+    }
+    set.mu.Unlock()
+    return set
+}
+
+// Check whether the set contains an item which has the same key as <item>.
+//
+// 判断集合中是否存在与item键名相同的元素项.
+func (set *HashedSet) Contains(item interface{}) bool {
+    key := set.hash(item)
+    set.mu.RLock()
+    _, exists := set.m[key]
+    set.mu.RUnlock()
+    return exists
+}
+
+// Get returns the stored item which has the same key as <item>,
+// and whether it exists in the set.
+//
+// 获得集合中与item键名相同的元素项, 以及该元素项是否存在.
+func (set *HashedSet) Get(item interface{}) (interface{}, bool) {
+    key := set.hash(item)
+    set.mu.RLock()
+    v, ok := set.m[key]
+    set.mu.RUnlock()
+    return v, ok
+}
+
+// Remove the items which have the same keys as the given items from the set.
+//
+// 从集合中删除与给定元素项键名相同的元素项(支持多个).
+func (set *HashedSet) Remove(item...interface{}) *HashedSet {
+    set.mu.Lock()
+    for _, v := range item {
+        delete(set.m, set.hash(v))
+    }
+    set.mu.Unlock()
+    return set
+}
+
+// Get size of the set.
+//
+// 获得集合大小。
+func (set *HashedSet) Size() int {
+    set.mu.RLock()
+    l := len(set.m)
+    set.mu.RUnlock()
+    return l
+}
+
+// Clear the set.
+//
+// 清空集合。
+func (set *HashedSet) Clear() *HashedSet {
+    set.mu.Lock()
+    set.m = make(map[interface{}]interface{})
+    set.mu.Unlock()
+    return set
+}
+
+// Get the copy of items from set as slice.
+//
+// 获得集合元素项列表.
+func (set *HashedSet) Slice() []interface{} {
+    set.mu.RLock()
+    i   := 0
+    ret := make([]interface{}, len(set.m))
+    for _, v := range set.m {
+        ret[i] = v
+        i++
+    }
+    set.mu.RUnlock()
+    return ret
+}
@@ -0,0 +1,173 @@
+// Copyright 2019 gf Author(https://github.com/gogf/gf). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gset
+
+import (
+    "errors"
+    "fmt"
+    "github.com/gogf/gf/g/internal/rwmutex"
+    "github.com/gogf/gf/g/util/gconv"
+    "hash/fnv"
+    "math"
+    "math/bits"
+)
+
+const (
+    // HLLSet默认精度(寄存器数量为2^14, 标准误差约为0.81%)
+    gDEFAULT_HLL_PRECISION = 14
+    // HLLSet最小精度
+    gMIN_HLL_PRECISION     = 4
+    // HLLSet最大精度
+    gMAX_HLL_PRECISION     = 16
+)
+
+// HLLSet is an approximate set based on HyperLogLog, which estimates the count of distinct items
+// using a fixed and small amount of memory, no matter how many items are added.
+// It does not store the items, so it does not support Contains, Remove or Slice by design.
+// Items are hashed with their types, so 1 and "1" are counted as two distinct items.
+//
+// With precision p, it uses 2^p one-byte registers, and the standard error of the estimation
+// is about 1.04/sqrt(2^p), eg: 0.81% with 16KB memory for the default precision 14.
+//
+// 基于HyperLogLog的近似集合, 使用固定且很小的内存估算不重复元素项的数量, 与添加的元素项数量无关.
+// 由于不存储元素项, 因此不支持Contains/Remove/Slice等操作. 元素项连同其类型计算哈希值, 因此1和"1"被计为两个不同的元素项.
+// 精度为p时使用2^p个单字节寄存器, 估算的标准误差约为1.04/sqrt(2^p), 例如默认精度14时使用16KB内存, 标准误差约为0.81%.
+type HLLSet struct {
+    mu        *rwmutex.RWMutex
+    precision uint8   // 精度p, 寄存器数量为2^p
+    registers []uint8 // 寄存器列表
+}
+
+// Create a HyperLogLog set with <precision>, which is in range [4, 16],
+// <precision> uses gDEFAULT_HLL_PRECISION if it's out of the range.
+// The param <unsafe> used to specify whether using array with un-concurrent-safety,
+// which is false in default, means concurrent-safe in default.
+//
+// 创建一个精度为precision的HyperLogLog集合(precision取值范围为[4, 16], 超出范围时使用默认精度)，
+// 参数unsafe用于指定是否用于非并发安全场景，默认为false，表示并发安全。
+func NewHLLSet(precision int, unsafe...bool) *HLLSet {
+    if precision < gMIN_HLL_PRECISION || precision > gMAX_HLL_PRECISION {
+        precision = gDEFAULT_HLL_PRECISION
+    }
+    return &HLLSet{
+        mu        : rwmutex.New(unsafe...),
+        precision : uint8(precision),
+        registers : make([]uint8, 1 << uint(precision)),
+    }
+}
+
+// hashItem returns the 64-bit hash of <item> in string form tagged with its type name,
+// so that items of different types with the same string form (eg: 1 and "1") are distinct.
+// It's the FNV-1a hash finalized by the mixer of MurmurHash3 for better bit distribution.
+//
+// 获得元素项带类型名称的字符串形式的64位哈希值, 使得字符串形式相同但类型不同的元素项(例如1和"1")被视为不同的元素项.
+// FNV-1a哈希值经过MurmurHash3的混合函数处理, 使得各位分布更均匀.
+func hashItem(item interface{}) uint64 {
+    h := fnv.New64a()
+    h.Write([]byte(fmt.Sprintf("%T:", item)))
+    h.Write([]byte(gconv.String(item)))
+    x := h.Sum64()
+    x ^= x >> 33
+    x *= 0xff51afd7ed558ccd
+    x ^= x >> 33
+    x *= 0xc4ceb9fe1a85ec53
+    x ^= x >> 33
+    return x
+}
+
+// Add one or multiple items to the set.
+//
+// 添加元素项到集合中(支持多个).
+func (set *HLLSet) Add(item...interface{}) *HLLSet {
+    p := set.precision
+    set.mu.Lock()
+    for _, v := range item {
+        h     := hashItem(v)
+        index := h >> (64 - p)
+        // 剩余位的前导零数量加1, 末尾补1保证结果不超过64-p+1.
+        rank  := uint8(bits.LeadingZeros64(h << p | 1 << (p - 1)) + 1)
+        if rank > set.registers[index] {
+            set.registers[index] = rank
+        }
+    }
+    set.mu.Unlock()
+    return set
+}
+
+// EstimateSize returns the estimated count of distinct items added to the set.
+//
+// 获得添加到集合中的不重复元素项的估算数量.
+func (set *HLLSet) EstimateSize() int {
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    m     := float64(len(set.registers))
+    sum   := 0.0
+    zeros := 0
+    for _, r := range set.registers {
+        sum += 1 / float64(uint64(1) << r)
+        if r == 0 {
+            zeros++
+        }
+    }
+    alpha := 0.0
+    switch len(set.registers) {
+        case 16: alpha = 0.673
+        case 32: alpha = 0.697
+        case 64: alpha = 0.709
+        default: alpha = 0.7213 / (1 + 1.079 / m)
+    }
+    estimate := alpha * m * m / sum
+    // 小基数时使用线性计数修正.
+    if estimate <= 2.5 * m && zeros > 0 {
+        estimate = m * math.Log(m / float64(zeros))
+    }
+    return int(estimate + 0.5)
+}
+
+// Precision returns the precision of the set.
+//
+// 获得集合的精度.
+func (set *HLLSet) Precision() int {
+    return int(set.precision)
+}
+
+// Merge merges the registers of <others> into the current set, after which the current set
+// estimates the count of distinct items added to any of them. It's useful for distributed counting.
+// All the sets must have the same precision, or else an error is returned and the current set is unchanged.
+//
+// 合并others集合的寄存器到当前集合, 合并后当前集合估算的是添加到其中任意一个集合的不重复元素项数量, 可用于分布式计数.
+// 所有集合的精度必须相同, 否则返回错误且当前集合不会被修改.
+func (set *HLLSet) Merge(others...*HLLSet) error {
+    for _, other := range others {
+        if other.precision != set.precision {
+            return errors.New("cannot merge HLLSet with different precision")
+        }
+    }
+    for _, other := range others {
+        if set == other {
+            continue
+        }
+        unlock := lockPair(set.mu, other.mu, true)
+        for i, r := range other.registers {
+            if r > set.registers[i] {
+                set.registers[i] = r
+            }
+        }
+        unlock()
+    }
+    return nil
+}
+
+// Clear the set.
+//
+// 清空集合。
+func (set *HLLSet) Clear() *HLLSet {
+    set.mu.Lock()
+    set.registers = make([]uint8, len(set.registers))
+    set.mu.Unlock()
+    return set
+}
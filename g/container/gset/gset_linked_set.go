@@ -0,0 +1,143 @@
+// Copyright 2019 gf Author(https://github.com/gogf/gf). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gset
+
+import (
+    "container/list"
+    "github.com/gogf/gf/g/internal/rwmutex"
+    "github.com/gogf/gf/g/util/gconv"
+    "strings"
+)
+
+// LinkedSet is a set which remembers the insertion order of its items.
+// Its Slice and Iterator visit the items in insertion order.
+//
+// 保持元素项插入顺序的集合, Slice及Iterator均按照插入顺序访问元素项.
+type LinkedSet struct {
+    mu   *rwmutex.RWMutex
+    m    map[interface{}]*list.Element
+    list *list.List
+}
+
+// Create a linked set, which contains un-repeated items in insertion order.
+// The param <unsafe> used to specify whether using array with un-concurrent-safety,
+// which is false in default, means concurrent-safe in default.
+//
+// 创建一个空的有序集合对象，参数unsafe用于指定是否用于非并发安全场景，默认为false，表示并发安全。
+func NewLinkedSet(unsafe...bool) *LinkedSet {
+    return &LinkedSet{
+        m    : make(map[interface{}]*list.Element),
+        mu   : rwmutex.New(unsafe...),
+        list : list.New(),
+    }
+}
+
+// Iterate the set in insertion order by given callback <f>,
+// if <f> returns true then continue iterating; or false to stop.
+//
+// 给定回调函数按照插入顺序对原始内容进行遍历，回调函数返回true表示继续遍历，否则停止遍历。
+func (set *LinkedSet) Iterator(f func (v interface{}) bool) *LinkedSet {
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    for e := set.list.Front(); e != nil; e = e.Next() {
+        if !f(e.Value) {
+            break
+        }
+    }
+    return set
+}
+
+// Add one or multiple items to the set.
+// The items that already exist in the set keep their original position.
+//
+// 添加元素项到集合中(支持多个), 已存在的元素项保持其原有位置.
+func (set *LinkedSet) Add(item...interface{}) *LinkedSet {
+    set.mu.Lock()
+    for _, v := range item {
+        if _, ok := set.m[v]; !ok {
+            set.m[v] = set.list.PushBack(v)
+        }
+    }
+    set.mu.Unlock()
+    return set
+}
+
+// Check whether the set contains <item>.
+//
+// 键是否存在.
+func (set *LinkedSet) Contains(item interface{}) bool {
+    set.mu.RLock()
+    _, exists := set.m[item]
+    set.mu.RUnlock()
+    return exists
+}
+
+// Remove one or multiple items from the set.
+// It does nothing for the items that are not in the set.
+//
+// 从集合中删除元素项(支持多个).
+func (set *LinkedSet) Remove(item...interface{}) *LinkedSet {
+    set.mu.Lock()
+    for _, v := range item {
+        if e, ok := set.m[v]; ok {
+            set.list.Remove(e)
+            delete(set.m, v)
+        }
+    }
+    set.mu.Unlock()
+    return set
+}
+
+// Get size of the set.
+//
+// 获得集合大小。
+func (set *LinkedSet) Size() int {
+    set.mu.RLock()
+    l := len(set.m)
+    set.mu.RUnlock()
+    return l
+}
+
+// Clear the set.
+//
+// 清空集合。
+func (set *LinkedSet) Clear() *LinkedSet {
+    set.mu.Lock()
+    set.m    = make(map[interface{}]*list.Element)
+    set.list = list.New()
+    set.mu.Unlock()
+    return set
+}
+
+// Get the copy of items from set as slice in insertion order.
+//
+// 按照插入顺序获得集合元素项列表.
+func (set *LinkedSet) Slice() []interface{} {
+    set.mu.RLock()
+    i   := 0
+    ret := make([]interface{}, len(set.m))
+    for e := set.list.Front(); e != nil; e = e.Next() {
+        ret[i] = e.Value
+        i++
+    }
+    set.mu.RUnlock()
+    return ret
+}
+
+// Join set items with a string in insertion order.
+//
+// 使用glue字符串按照插入顺序串连当前集合的元素项，构造成新的字符串返回。
+func (set *LinkedSet) Join(glue string) string {
+    return strings.Join(gconv.Strings(set.Slice()), glue)
+}
+
+// Return set items as a string, which are joined by char ','.
+//
+// 使用','串连当前集合的元素项，构造成新的字符串返回。
+func (set *LinkedSet) String() string {
+    return set.Join(",")
+}
@@ -0,0 +1,143 @@
+// Copyright 2019 gf Author(https://github.com/gogf/gf). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gset
+
+import (
+    "container/list"
+    "github.com/gogf/gf/g/internal/rwmutex"
+)
+
+// LRUSet is a size-bounded set, which evicts the least recently used item
+// when its size exceeds the capacity.
+// An item is used when it is added or touched, Contains does not refresh the recency.
+//
+// 限定大小的集合, 当集合大小超过容量时淘汰最近最少使用的元素项.
+// 元素项在被添加或者Touch时视为被使用, Contains不会刷新元素项的使用记录.
+type LRUSet struct {
+    mu   *rwmutex.RWMutex
+    m    map[interface{}]*list.Element
+    list *list.List // 元素项使用顺序, 表头为最近最少使用
+    cap  int
+}
+
+// Create a LRU set with capacity <cap>, the capacity is treated as 1 if <cap> is less than 1.
+// The param <unsafe> used to specify whether using array with un-concurrent-safety,
+// which is false in default, means concurrent-safe in default.
+//
+// 创建一个容量为cap的LRU集合对象(cap小于1时按照1处理)，参数unsafe用于指定是否用于非并发安全场景，默认为false，表示并发安全。
+func NewLRUSet(cap int, unsafe...bool) *LRUSet {
+    if cap < 1 {
+        cap = 1
+    }
+    return &LRUSet{
+        m    : make(map[interface{}]*list.Element),
+        mu   : rwmutex.New(unsafe...),
+        list : list.New(),
+        cap  : cap,
+    }
+}
+
+// Add one or multiple items to the set, which marks them as the most recently used.
+// The least recently used items are evicted if the size exceeds the capacity.
+//
+// 添加元素项到集合中(支持多个)并标记为最近使用, 当集合大小超过容量时淘汰最近最少使用的元素项.
+func (set *LRUSet) Add(item...interface{}) *LRUSet {
+    set.mu.Lock()
+    for _, v := range item {
+        if e, ok := set.m[v]; ok {
+            set.list.MoveToBack(e)
+            continue
+        }
+        set.m[v] = set.list.PushBack(v)
+        if len(set.m) > set.cap {
+            delete(set.m, set.list.Remove(set.list.Front()))
+        }
+    }
+    set.mu.Unlock()
+    return set
+}
+
+// Touch marks <item> as the most recently used, and returns whether it exists in the set.
+//
+// 将元素项标记为最近使用, 并返回该元素项是否存在.
+func (set *LRUSet) Touch(item interface{}) bool {
+    set.mu.Lock()
+    defer set.mu.Unlock()
+    if e, ok := set.m[item]; ok {
+        set.list.MoveToBack(e)
+        return true
+    }
+    return false
+}
+
+// Check whether the set contains <item>.
+//
+// 键是否存在.
+func (set *LRUSet) Contains(item interface{}) bool {
+    set.mu.RLock()
+    _, exists := set.m[item]
+    set.mu.RUnlock()
+    return exists
+}
+
+// Remove one or multiple items from the set.
+//
+// 从集合中删除元素项(支持多个).
+func (set *LRUSet) Remove(item...interface{}) *LRUSet {
+    set.mu.Lock()
+    for _, v := range item {
+        if e, ok := set.m[v]; ok {
+            set.list.Remove(e)
+            delete(set.m, v)
+        }
+    }
+    set.mu.Unlock()
+    return set
+}
+
+// Get size of the set.
+//
+// 获得集合大小。
+func (set *LRUSet) Size() int {
+    set.mu.RLock()
+    l := len(set.m)
+    set.mu.RUnlock()
+    return l
+}
+
+// Get capacity of the set.
+//
+// 获得集合容量。
+func (set *LRUSet) Cap() int {
+    return set.cap
+}
+
+// Clear the set.
+//
+// 清空集合。
+func (set *LRUSet) Clear() *LRUSet {
+    set.mu.Lock()
+    set.m    = make(map[interface{}]*list.Element)
+    set.list = list.New()
+    set.mu.Unlock()
+    return set
+}
+
+// Get the copy of items from set as slice, ordered from the least to the most recently used.
+//
+// 获得集合元素项列表, 按照最近最少使用到最近使用的顺序排列.
+func (set *LRUSet) Slice() []interface{} {
+    set.mu.RLock()
+    i   := 0
+    ret := make([]interface{}, len(set.m))
+    for e := set.list.Front(); e != nil; e = e.Next() {
+        ret[i] = e.Value
+        i++
+    }
+    set.mu.RUnlock()
+    return ret
+}
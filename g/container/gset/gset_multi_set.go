@@ -0,0 +1,146 @@
+// Copyright 2019 gf Author(https://github.com/gogf/gf). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gset
+
+import (
+    "github.com/gogf/gf/g/internal/rwmutex"
+)
+
+// MultiSet is a set which counts the occurrences of its items, also known as bag.
+//
+// 记录元素项出现次数的集合(也称为bag).
+type MultiSet struct {
+    mu   *rwmutex.RWMutex
+    m    map[interface{}]int
+    size int // 所有元素项出现次数之和
+}
+
+// Create a multi set.
+// The param <unsafe> used to specify whether using array with un-concurrent-safety,
+// which is false in default, means concurrent-safe in default.
+//
+// 创建一个空的多重集合对象，参数unsafe用于指定是否用于非并发安全场景，默认为false，表示并发安全。
+func NewMultiSet(unsafe...bool) *MultiSet {
+    return &MultiSet{
+        m  : make(map[interface{}]int),
+        mu : rwmutex.New(unsafe...),
+    }
+}
+
+// Iterate the distinct items and their counts by given callback <f>,
+// if <f> returns true then continue iterating; or false to stop.
+//
+// 给定回调函数对不重复的元素项及其出现次数进行遍历，回调函数返回true表示继续遍历，否则停止遍历。
+func (set *MultiSet) Iterator(f func (v interface{}, count int) bool) *MultiSet {
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    for k, c := range set.m {
+        if !f(k, c) {
+            break
+        }
+    }
+    return set
+}
+
+// Add one or multiple items to the set, which increases the count of each item by one.
+//
+// 添加元素项到集合中(支持多个), 每个元素项的出现次数加1.
+func (set *MultiSet) Add(item...interface{}) *MultiSet {
+    set.mu.Lock()
+    for _, v := range item {
+        set.m[v]++
+    }
+    set.size += len(item)
+    set.mu.Unlock()
+    return set
+}
+
+// Check whether the set contains <item>.
+//
+// 键是否存在.
+func (set *MultiSet) Contains(item interface{}) bool {
+    set.mu.RLock()
+    _, exists := set.m[item]
+    set.mu.RUnlock()
+    return exists
+}
+
+// Count returns the count of <item> in the set, which is 0 if it does not exist.
+//
+// 获得元素项在集合中的出现次数, 不存在时返回0.
+func (set *MultiSet) Count(item interface{}) int {
+    set.mu.RLock()
+    c := set.m[item]
+    set.mu.RUnlock()
+    return c
+}
+
+// Remove one or multiple items from the set, which decreases the count of each item by one,
+// and deletes the item when its count reaches zero.
+//
+// 从集合中删除元素项(支持多个), 每个元素项的出现次数减1, 出现次数为0时删除该元素项.
+func (set *MultiSet) Remove(item...interface{}) *MultiSet {
+    set.mu.Lock()
+    for _, v := range item {
+        if c, ok := set.m[v]; ok {
+            if c > 1 {
+                set.m[v] = c - 1
+            } else {
+                delete(set.m, v)
+            }
+            set.size--
+        }
+    }
+    set.mu.Unlock()
+    return set
+}
+
+// Get size of the set, which is the total count of all items.
+//
+// 获得集合大小(所有元素项出现次数之和)。
+func (set *MultiSet) Size() int {
+    set.mu.RLock()
+    l := set.size
+    set.mu.RUnlock()
+    return l
+}
+
+// Distinct returns the count of distinct items in the set.
+//
+// 获得集合中不重复元素项的数量。
+func (set *MultiSet) Distinct() int {
+    set.mu.RLock()
+    l := len(set.m)
+    set.mu.RUnlock()
+    return l
+}
+
+// Clear the set.
+//
+// 清空集合。
+func (set *MultiSet) Clear() *MultiSet {
+    set.mu.Lock()
+    set.m    = make(map[interface{}]int)
+    set.size = 0
+    set.mu.Unlock()
+    return set
+}
+
+// Get the copy of distinct items from set as slice.
+//
+// 获得集合中不重复的元素项列表.
+func (set *MultiSet) Slice() []interface{} {
+    set.mu.RLock()
+    i   := 0
+    ret := make([]interface{}, len(set.m))
+    for item := range set.m {
+        ret[i] = item
+        i++
+    }
+    set.mu.RUnlock()
+    return ret
+}
@@ -0,0 +1,128 @@
+// Copyright 2019 gf Author(https://github.com/gogf/gf). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gset
+
+// ReadonlySet is a read-only view of a Set, which exposes no mutating method.
+// It shares the underlying items with the Set, so the changes of the Set are visible through the view.
+//
+// Set的只读视图, 不提供任何修改方法. 只读视图与原集合共享数据, 因此原集合的修改对视图可见.
+type ReadonlySet struct {
+    set *Set
+}
+
+// Readonly returns a read-only view of the set.
+//
+// 获得当前集合的只读视图.
+func (set *Set) Readonly() *ReadonlySet {
+    return &ReadonlySet{set : set}
+}
+
+// See Set.Iterator.
+//
+// 同Set.Iterator.
+func (r *ReadonlySet) Iterator(f func (v interface{}) bool) *ReadonlySet {
+    r.set.Iterator(f)
+    return r
+}
+
+// See Set.Contains.
+//
+// 同Set.Contains.
+func (r *ReadonlySet) Contains(item interface{}) bool {
+    return r.set.Contains(item)
+}
+
+// See Set.Size.
+//
+// 同Set.Size.
+func (r *ReadonlySet) Size() int {
+    return r.set.Size()
+}
+
+// See Set.Slice.
+//
+// 同Set.Slice.
+func (r *ReadonlySet) Slice() []interface{} {
+    return r.set.Slice()
+}
+
+// See Set.Join.
+//
+// 同Set.Join.
+func (r *ReadonlySet) Join(glue string) string {
+    return r.set.Join(glue)
+}
+
+// See Set.String.
+//
+// 同Set.String.
+func (r *ReadonlySet) String() string {
+    return r.set.String()
+}
+
+// See Set.MarshalJSON.
+//
+// 同Set.MarshalJSON.
+func (r *ReadonlySet) MarshalJSON() ([]byte, error) {
+    return r.set.MarshalJSON()
+}
+
+// Clone returns a new mutable concurrent-safe set with a copy of the items.
+//
+// 复制当前只读视图的数据并返回新的可修改的并发安全集合.
+func (r *ReadonlySet) Clone() *Set {
+    return r.set.Clone()
+}
+
+// See Set.Equal.
+//
+// 同Set.Equal.
+func (r *ReadonlySet) Equal(other *Set) bool {
+    return r.set.Equal(other)
+}
+
+// See Set.IsSubsetOf.
+//
+// 同Set.IsSubsetOf.
+func (r *ReadonlySet) IsSubsetOf(other *Set) bool {
+    return r.set.IsSubsetOf(other)
+}
+
+// See Set.IsSupersetOf.
+//
+// 同Set.IsSupersetOf.
+func (r *ReadonlySet) IsSupersetOf(other *Set) bool {
+    return r.set.IsSupersetOf(other)
+}
+
+// See Set.Union.
+//
+// 同Set.Union.
+func (r *ReadonlySet) Union(others ... *Set) *Set {
+    return r.set.Union(others...)
+}
+
+// See Set.Diff.
+//
+// 同Set.Diff.
+func (r *ReadonlySet) Diff(others...*Set) *Set {
+    return r.set.Diff(others...)
+}
+
+// See Set.Intersect.
+//
+// 同Set.Intersect.
+func (r *ReadonlySet) Intersect(others...*Set) *Set {
+    return r.set.Intersect(others...)
+}
+
+// See Set.Complement.
+//
+// 同Set.Complement.
+func (r *ReadonlySet) Complement(full *Set) *Set {
+    return r.set.Complement(full)
+}
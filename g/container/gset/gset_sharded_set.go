@@ -0,0 +1,142 @@
+// Copyright 2019 gf Author(https://github.com/gogf/gf). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gset
+
+import (
+    "github.com/gogf/gf/g/encoding/ghash"
+    "github.com/gogf/gf/g/util/gconv"
+)
+
+const (
+    // ShardedSet默认分片数量
+    gDEFAULT_SHARD_COUNT = 32
+)
+
+// ShardedSet is a concurrent-safe set which partitions its items across
+// multiple shards by the hash of the items, each shard has its own lock,
+// which reduces the lock contention under heavy concurrent writes.
+//
+// 分片集合, 按照元素项的哈希值将元素项分布到多个分片中, 每个分片使用独立的锁,
+// 用于降低高并发写入时的锁竞争.
+type ShardedSet struct {
+    shards []*Set
+}
+
+// Create a sharded set with <count> shards, <count> uses gDEFAULT_SHARD_COUNT if it's less than 1.
+// The param <unsafe> used to specify whether using array with un-concurrent-safety,
+// which is false in default, means concurrent-safe in default.
+//
+// 创建一个分片数量为count的分片集合(count小于1时使用默认分片数量)，参数unsafe用于指定是否用于非并发安全场景，默认为false，表示并发安全。
+func NewShardedSet(count int, unsafe...bool) *ShardedSet {
+    if count < 1 {
+        count = gDEFAULT_SHARD_COUNT
+    }
+    set := &ShardedSet{
+        shards : make([]*Set, count),
+    }
+    for i := 0; i < count; i++ {
+        set.shards[i] = NewSet(unsafe...)
+    }
+    return set
+}
+
+// shard returns the shard which <item> belongs to.
+//
+// 获得元素项所属的分片.
+func (set *ShardedSet) shard(item interface{}) *Set {
+    return set.shards[ghash.BKDRHash([]byte(gconv.String(item))) % uint32(len(set.shards))]
+}
+
+// Iterate the set by given callback <f> shard by shard,
+// if <f> returns true then continue iterating; or false to stop.
+//
+// 给定回调函数逐个分片对原始内容进行遍历，回调函数返回true表示继续遍历，否则停止遍历。
+func (set *ShardedSet) Iterator(f func (v interface{}) bool) *ShardedSet {
+    stop := false
+    for _, shard := range set.shards {
+        shard.Iterator(func(v interface{}) bool {
+            stop = !f(v)
+            return !stop
+        })
+        if stop {
+            break
+        }
+    }
+    return set
+}
+
+// Add one or multiple items to the set.
+//
+// 添加元素项到集合中(支持多个).
+func (set *ShardedSet) Add(item...interface{}) *ShardedSet {
+    for _, v := range item {
+        set.shard(v).Add(v)
+    }
+    return set
+}
+
+// Check whether the set contains <item>.
+//
+// 键是否存在.
+func (set *ShardedSet) Contains(item interface{}) bool {
+    return set.shard(item).Contains(item)
+}
+
+// Remove one or multiple items from the set.
+//
+// 从集合中删除元素项(支持多个).
+func (set *ShardedSet) Remove(item...interface{}) *ShardedSet {
+    for _, v := range item {
+        set.shard(v).Remove(v)
+    }
+    return set
+}
+
+// Get size of the set, which is the sum of the sizes of all shards.
+//
+// 获得集合大小(所有分片大小之和)。
+func (set *ShardedSet) Size() int {
+    size := 0
+    for _, shard := range set.shards {
+        size += shard.Size()
+    }
+    return size
+}
+
+// Clear the set.
+//
+// 清空集合。
+func (set *ShardedSet) Clear() *ShardedSet {
+    for _, shard := range set.shards {
+        shard.Clear()
+    }
+    return set
+}
+
+// Get the copy of items from all shards as slice.
+// Note that the shards are read one by one, so it's not an atomic snapshot of the whole set.
+//
+// 获得所有分片的元素项列表, 注意各分片是逐个读取的, 因此并不是整个集合的原子快照.
+func (set *ShardedSet) Slice() []interface{} {
+    ret := make([]interface{}, 0)
+    for _, shard := range set.shards {
+        ret = append(ret, shard.Slice()...)
+    }
+    return ret
+}
+
+// Set returns a new concurrent-safe Set containing the items of all shards,
+// which can be used for the set operations like Union, Diff and Intersect.
+//
+// 返回包含所有分片元素项的新并发安全集合, 可用于Union/Diff/Intersect等集合运算.
+func (set *ShardedSet) Set() *Set {
+    newSet := NewSet()
+    for _, shard := range set.shards {
+        newSet.Merge(shard)
+    }
+    return newSet
+}
@@ -8,32 +8,68 @@
 package gset
 
 import (
+    "encoding/json"
     "github.com/gogf/gf/g/internal/rwmutex"
+    "github.com/gogf/gf/g/util/gconv"
     "strings"
 )
 
-type StringSet struct {
+// StrSet is a set of string items, which is backed by map[string]struct{}
+// to avoid the boxing cost of interface{} items in Set.
+// The new sets returned by the set operations have the same concurrent-safety as the current set.
+//
+// string类型的集合, 底层使用map[string]struct{}存储, 避免了Set使用interface{}带来的装箱开销.
+// 集合运算返回的新集合与当前集合的并发安全性相同.
+type StrSet struct {
 	mu *rwmutex.RWMutex
 	m  map[string]struct{}
 }
 
+// StringSet is the former name of StrSet, kept for compatibility.
+//
+// StrSet的旧名称, 保留用于兼容.
+type StringSet = StrSet
+
 // Create a set, which contains un-repeated items.
 // The param <unsafe> used to specify whether using array with un-concurrent-safety,
 // which is false in default, means concurrent-safe in default.
 //
 // 创建一个空的集合对象，参数unsafe用于指定是否用于非并发安全场景，默认为false，表示并发安全。
-func NewStringSet(unsafe...bool) *StringSet {
-	return &StringSet {
+func NewStrSet(unsafe...bool) *StrSet {
+	return &StrSet {
 		m  : make(map[string]struct{}),
 		mu : rwmutex.New(unsafe...),
 	}
 }
 
+// See NewStrSet.
+//
+// 同NewStrSet.
+func NewStringSet(unsafe...bool) *StrSet {
+    return NewStrSet(unsafe...)
+}
+
+// NewStrSetFrom creates a set from the items of slice <items>.
+// The param <unsafe> used to specify whether using array with un-concurrent-safety,
+// which is false in default, means concurrent-safe in default.
+//
+// 通过string slice创建集合对象，参数unsafe用于指定是否用于非并发安全场景，默认为false，表示并发安全。
+func NewStrSetFrom(items []string, unsafe...bool) *StrSet {
+    m := make(map[string]struct{}, len(items))
+    for _, v := range items {
+        m[v] = struct{}{}
+    }
+    return &StrSet{
+        m  : m,
+        mu : rwmutex.New(unsafe...),
+    }
+}
+
 // Iterate the set by given callback <f>,
 // if <f> returns true then continue iterating; or false to stop.
 //
 // 给定回调函数对原始内容进行遍历，回调函数返回true表示继续遍历，否则停止遍历。
-func (set *StringSet) Iterator(f func (v string) bool) *StringSet {
+func (set *StrSet) Iterator(f func (v string) bool) *StrSet {
     set.mu.RLock()
     defer set.mu.RUnlock()
 	for k, _ := range set.m {
@@ -44,10 +80,25 @@ func (set *StringSet) Iterator(f func (v string) bool) *StringSet {
 	return set
 }
 
+// Walk applies callback function <f> to every item of the set in place.
+// Items mapped to the same result are collapsed into one item.
+//
+// 使用回调函数f对集合中的每个元素项进行处理(原地修改), 处理结果相同的元素项会合并为一项.
+func (set *StrSet) Walk(f func(item string) string) *StrSet {
+    set.mu.Lock()
+    defer set.mu.Unlock()
+    m := make(map[string]struct{}, len(set.m))
+    for k, v := range set.m {
+        m[f(k)] = v
+    }
+    set.m = m
+    return set
+}
+
 // Add one or multiple items to the set.
 //
 // 添加元素项到集合中(支持多个).
-func (set *StringSet) Add(item...string) *StringSet {
+func (set *StrSet) Add(item...string) *StrSet {
 	set.mu.Lock()
 	for _, v := range item {
 		set.m[v] = struct{}{}
@@ -56,30 +107,98 @@ func (set *StringSet) Add(item...string) *StringSet {
 	return set
 }
 
+// AddIfNotExist adds <item> to the set if it does not exist in the set,
+// and returns true if the item is added, or else false.
+// The checking and adding are done within one write lock, which is atomic.
+//
+// 当元素项不存在时将其添加到集合中并返回true, 否则返回false(检查与添加为原子操作).
+func (set *StrSet) AddIfNotExist(item string) bool {
+    set.mu.Lock()
+    defer set.mu.Unlock()
+    if _, ok := set.m[item]; !ok {
+        set.m[item] = struct{}{}
+        return true
+    }
+    return false
+}
+
+// AddIfNotExistFunc adds <item> to the set if it does not exist in the set and
+// function <f> returns true, and returns true if the item is added, or else false.
+// Note that <f> is called within the write lock, so it must not call any method of the set,
+// or else it will be deadlocked.
+//
+// 当元素项不存在且f返回true时将其添加到集合中并返回true, 否则返回false.
+// 注意f在写锁内执行, 因此f中不能调用当前集合的任何方法, 否则会造成死锁.
+func (set *StrSet) AddIfNotExistFunc(item string, f func() bool) bool {
+    set.mu.Lock()
+    defer set.mu.Unlock()
+    if _, ok := set.m[item]; !ok && f() {
+        set.m[item] = struct{}{}
+        return true
+    }
+    return false
+}
+
 // Check whether the set contains <item>.
 //
 // 键是否存在.
-func (set *StringSet) Contains(item string) bool {
+func (set *StrSet) Contains(item string) bool {
 	set.mu.RLock()
 	_, exists := set.m[item]
 	set.mu.RUnlock()
 	return exists
 }
 
-// Remove <item> from set.
+// ContainsI checks whether the set contains <item> case-insensitively using strings.EqualFold.
+// Note that it scans the items linearly if <item> is not exactly in the set, which costs O(n).
 //
-// 删除元素项。
-func (set *StringSet) Remove(item string) *StringSet {
-	set.mu.Lock()
-	delete(set.m, item)
-	set.mu.Unlock()
+// 不区分大小写判断集合是否包含item(使用strings.EqualFold比较).
+// 注意当item不是精确存在于集合中时需要线性遍历元素项, 时间复杂度为O(n).
+func (set *StrSet) ContainsI(item string) bool {
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    if _, ok := set.m[item]; ok {
+        return true
+    }
+    for k := range set.m {
+        if strings.EqualFold(k, item) {
+            return true
+        }
+    }
+    return false
+}
+
+// Remove one or multiple items from the set.
+// It does nothing for the items that are not in the set.
+//
+// 从集合中删除元素项(支持多个).
+func (set *StrSet) Remove(item...string) *StrSet {
+    set.mu.Lock()
+    for _, v := range item {
+        delete(set.m, v)
+    }
+    set.mu.Unlock()
     return set
 }
 
+// Pop randomly removes and returns an item from the set,
+// and whether an item is returned, which is false if the set is empty.
+//
+// 随机从集合中移除并返回一个元素项, 以及是否成功返回(集合为空时为false).
+func (set *StrSet) Pop() (string, bool) {
+    set.mu.Lock()
+    defer set.mu.Unlock()
+    for k := range set.m {
+        delete(set.m, k)
+        return k, true
+    }
+    return "", false
+}
+
 // Get size of the set.
 //
 // 获得集合大小。
-func (set *StringSet) Size() int {
+func (set *StrSet) Size() int {
 	set.mu.RLock()
 	l := len(set.m)
 	set.mu.RUnlock()
@@ -89,17 +208,32 @@ func (set *StringSet) Size() int {
 // Clear the set.
 //
 // 清空集合。
-func (set *StringSet) Clear() *StringSet {
+func (set *StrSet) Clear() *StrSet {
 	set.mu.Lock()
 	set.m = make(map[string]struct{})
 	set.mu.Unlock()
     return set
 }
 
+// Clone returns a new concurrent-safe set with a copy of the items of current set,
+// no matter whether the current set is concurrent-safe or not.
+//
+// 复制当前集合并返回新的并发安全集合(无论当前集合是否为并发安全).
+func (set *StrSet) Clone() *StrSet {
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    newSet := NewStrSet()
+    newSet.m = make(map[string]struct{}, len(set.m))
+    for k, v := range set.m {
+        newSet.m[k] = v
+    }
+    return newSet
+}
+
 // Get the copy of items from set as slice.
 //
 // 获得集合元素项列表.
-func (set *StringSet) Slice() []string {
+func (set *StrSet) Slice() []string {
 	set.mu.RLock()
 	ret := make([]string, len(set.m))
 	i := 0
@@ -112,24 +246,36 @@ func (set *StringSet) Slice() []string {
 	return ret
 }
 
+// Calculate the sum of items in the set.
+//
+// 对集合中的元素项求和(将元素值转换为int类型后叠加)。
+func (set *StrSet) Sum() (sum int) {
+    set.mu.RLock()
+    defer set.mu.RUnlock()
+    for k, _ := range set.m {
+        sum += gconv.Int(k)
+    }
+    return
+}
+
 // Join set items with a string.
 //
 // 使用glue字符串串连当前集合的元素项，构造成新的字符串返回。
-func (set *StringSet) Join(glue string) string {
-    return strings.Join(set.Slice(), ",")
+func (set *StrSet) Join(glue string) string {
+    return strings.Join(set.Slice(), glue)
 }
 
 // Return set items as a string, which are joined by char ','.
 //
 // 使用glue字符串串连当前集合的元素项，构造成新的字符串返回。
-func (set *StringSet) String() string {
+func (set *StrSet) String() string {
     return set.Join(",")
 }
 
 // Lock writing by callback function f.
 //
 // 使用自定义方法执行加锁修改操作。
-func (set *StringSet) LockFunc(f func(m map[string]struct{})) *StringSet {
+func (set *StrSet) LockFunc(f func(m map[string]struct{})) *StrSet {
 	set.mu.Lock(true)
 	defer set.mu.Unlock(true)
 	f(set.m)
@@ -139,7 +285,7 @@ func (set *StringSet) LockFunc(f func(m map[string]struct{})) *StringSet {
 // Lock reading by callback function f.
 //
 // 使用自定义方法执行加锁读取操作。
-func (set *StringSet) RLockFunc(f func(m map[string]struct{})) *StringSet {
+func (set *StrSet) RLockFunc(f func(m map[string]struct{})) *StrSet {
 	set.mu.RLock(true)
 	defer set.mu.RUnlock(true)
 	f(set.m)
@@ -149,14 +295,11 @@ func (set *StringSet) RLockFunc(f func(m map[string]struct{})) *StringSet {
 // Check whether the two sets equal.
 //
 // 判断两个集合是否相等.
-func (set *StringSet) Equal(other *StringSet) bool {
+func (set *StrSet) Equal(other *StrSet) bool {
 	if set == other {
 		return true
 	}
-	set.mu.RLock()
-	defer set.mu.RUnlock()
-	other.mu.RLock()
-	defer other.mu.RUnlock()
+    defer lockPair(set.mu, other.mu, false)()
 	if len(set.m) != len(other.m) {
 		return false
 	}
@@ -171,14 +314,11 @@ func (set *StringSet) Equal(other *StringSet) bool {
 // Check whether the current set is sub-set of <other>.
 //
 // 判断当前集合是否为other集合的子集.
-func (set *StringSet) IsSubsetOf(other *StringSet) bool {
+func (set *StrSet) IsSubsetOf(other *StrSet) bool {
 	if set == other {
 		return true
 	}
-	set.mu.RLock()
-	defer set.mu.RUnlock()
-	other.mu.RLock()
-	defer other.mu.RUnlock()
+    defer lockPair(set.mu, other.mu, false)()
 	for key := range set.m {
 		if _, ok := other.m[key]; !ok {
 			return false
@@ -187,50 +327,69 @@ func (set *StringSet) IsSubsetOf(other *StringSet) bool {
 	return true
 }
 
+// Check whether the current set is super-set of <other>.
+//
+// 判断当前集合是否为other集合的超集.
+func (set *StrSet) IsSupersetOf(other *StrSet) bool {
+    return other.IsSubsetOf(set)
+}
+
 // Returns a new set which is the union of <set> and <other>.
 // Which means, all the items in <newSet> is in <set> or in <other>.
 //
 // 并集, 返回新的集合：属于set或属于others的元素为元素的集合.
-func (set *StringSet) Union(others ... *StringSet) (newSet *StringSet) {
-    newSet = NewStringSet(true)
+func (set *StrSet) Union(others ... *StrSet) (newSet *StrSet) {
+    newSet = NewStrSet(!set.mu.IsSafe())
     set.mu.RLock()
-    defer set.mu.RUnlock()
+    for k, v := range set.m {
+        newSet.m[k] = v
+    }
+    set.mu.RUnlock()
     for _, other := range others {
-        if set != other {
-            other.mu.RLock()
+        if set == other {
+            continue
         }
-        for k, v := range set.m {
+        other.mu.RLock()
+        for k, v := range other.m {
             newSet.m[k] = v
         }
-        if set != other {
-            for k, v := range other.m {
-                newSet.m[k] = v
-            }
-        }
-        if set != other {
-            other.mu.RUnlock()
-        }
+        other.mu.RUnlock()
     }
-
     return
 }
 
-// Returns a new set which is the difference set from <set> to <other>.
-// Which means, all the items in <newSet> is in <set> and not in <other>.
+// Returns a new set which is the difference set from <set> to <others>.
+// Which means, all the items in <newSet> is in <set> and not in any of <others>.
 //
-// 差集, 返回新的集合: 属于set且不属于others的元素为元素的集合.
-func (set *StringSet) Diff(others...*StringSet) (newSet *StringSet) {
-    newSet = NewStringSet(true)
-    set.mu.RLock()
-    defer set.mu.RUnlock()
+// 差集, 返回新的集合: 属于set且不属于任何others的元素为元素的集合.
+func (set *StrSet) Diff(others...*StrSet) (newSet *StrSet) {
+    newSet = NewStrSet(!set.mu.IsSafe())
     for _, other := range others {
+        // 集合与自身的差集为空集合.
         if set == other {
-            continue
+            return
+        }
+    }
+    set.mu.RLock()
+    for k, v := range set.m {
+        newSet.m[k] = v
+    }
+    set.mu.RUnlock()
+    // 每次只对一个集合加锁, 无需考虑多个集合间的加锁顺序.
+    for _, other := range others {
+        if len(newSet.m) == 0 {
+            break
         }
         other.mu.RLock()
-        for k, v := range set.m {
-            if _, ok := other.m[k]; !ok {
-                newSet.m[k] = v
+        if len(other.m) < len(newSet.m) {
+            for k := range other.m {
+                delete(newSet.m, k)
+            }
+        } else {
+            for k := range newSet.m {
+                if _, ok := other.m[k]; ok {
+                    delete(newSet.m, k)
+                }
             }
         }
         other.mu.RUnlock()
@@ -238,25 +397,43 @@ func (set *StringSet) Diff(others...*StringSet) (newSet *StringSet) {
     return
 }
 
-// Returns a new set which is the intersection from <set> to <other>.
-// Which means, all the items in <newSet> is in <set> and also in <other>.
+// Returns a new set which is the intersection from <set> to <others>.
+// Which means, all the items in <newSet> is in <set> and also in all of <others>.
+// It iterates the smallest one of the sets and probes the rest, which minimizes the comparisons,
+// so it iterates nothing if any of the sets is empty. Each set is read-locked only once.
 //
-// 交集, 返回新的集合: 属于set且属于others的元素为元素的集合.
-func (set *StringSet) Intersect(others...*StringSet) (newSet *StringSet) {
-    newSet = NewStringSet(true)
-    set.mu.RLock()
-    defer set.mu.RUnlock()
+// 交集, 返回新的集合: 属于set且属于所有others的元素为元素的集合.
+// 遍历元素项最少的集合并在其余集合中查找, 以减少比较次数, 因此任一集合为空时不会遍历任何元素项. 每个集合只加一次读锁.
+func (set *StrSet) Intersect(others...*StrSet) (newSet *StrSet) {
+    newSet = NewStrSet(!set.mu.IsSafe())
+    if len(others) == 0 {
+        return
+    }
+    sets := append([]*StrSet{set}, others...)
+    mus  := make([]*rwmutex.RWMutex, len(sets))
+    for i, s := range sets {
+        mus[i] = s.mu
+    }
+    defer rlockAll(mus)()
+    smallest := set
     for _, other := range others {
-        if set != other {
-            other.mu.RLock()
+        if len(other.m) < len(smallest.m) {
+            smallest = other
         }
-        for k, v := range set.m {
-            if _, ok := other.m[k]; ok {
-                newSet.m[k] = v
+    }
+    for k, v := range smallest.m {
+        found := true
+        for _, s := range sets {
+            if s == smallest {
+                continue
+            }
+            if _, ok := s.m[k]; !ok {
+                found = false
+                break
             }
         }
-        if set != other {
-            other.mu.RUnlock()
+        if found {
+            newSet.m[k] = v
         }
     }
     return
@@ -267,14 +444,9 @@ func (set *StringSet) Intersect(others...*StringSet) (newSet *StringSet) {
 //
 // 补集, 返回新的集合: (前提: set应当为full的子集)属于全集full不属于集合set的元素组成的集合.
 // 如果给定的full集合不是set的全集时，返回full与set的差集.
-func (set *StringSet) Complement(full *StringSet) (newSet *StringSet) {
-    newSet = NewStringSet(true)
-    set.mu.RLock()
-    defer set.mu.RUnlock()
-    if set != full {
-        full.mu.RLock()
-        defer full.mu.RUnlock()
-    }
+func (set *StrSet) Complement(full *StrSet) (newSet *StrSet) {
+    newSet = NewStrSet(!set.mu.IsSafe())
+    defer lockPair(set.mu, full.mu, false)()
     for k, v := range full.m {
         if _, ok := set.m[k]; !ok {
             newSet.m[k] = v
@@ -282,3 +454,53 @@ func (set *StringSet) Complement(full *StringSet) (newSet *StringSet) {
     }
     return
 }
+
+// MarshalJSON implements the interface MarshalJSON for json.Marshal,
+// which encodes the set as a JSON array of its items.
+//
+// 实现json.Marshal接口，将集合编码为JSON数组(空集合编码为[]).
+func (set *StrSet) MarshalJSON() ([]byte, error) {
+    if set == nil {
+        return []byte("[]"), nil
+    }
+    return json.Marshal(set.Slice())
+}
+
+// UnmarshalJSON implements the interface UnmarshalJSON for json.Unmarshal,
+// which clears the set and fills it with the items of given JSON array <b>.
+// A JSON null leaves the set empty, and any other non-array input returns an error.
+//
+// 实现json.Unmarshal接口，清空集合并使用给定JSON数组的元素项填充集合.
+func (set *StrSet) UnmarshalJSON(b []byte) error {
+    var items []string
+    if err := json.Unmarshal(b, &items); err != nil {
+        return err
+    }
+    if set.mu == nil {
+        set.mu = rwmutex.New()
+    }
+    set.mu.Lock()
+    defer set.mu.Unlock()
+    set.m = make(map[string]struct{}, len(items))
+    for _, v := range items {
+        set.m[v] = struct{}{}
+    }
+    return nil
+}
+
+// Merge adds all the items of <others> into the current set, without creating a new set.
+//
+// 合并others集合中的所有元素项到当前集合中(不创建新集合).
+func (set *StrSet) Merge(others...*StrSet) *StrSet {
+    for _, other := range others {
+        if set == other {
+            continue
+        }
+        unlock := lockPair(set.mu, other.mu, true)
+        for k, v := range other.m {
+            set.m[k] = v
+        }
+        unlock()
+    }
+    return set
+}
@@ -0,0 +1,181 @@
+// Copyright 2019 gf Author(https://github.com/gogf/gf). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gset
+
+import (
+    "container/list"
+    "github.com/gogf/gf/g/internal/rwmutex"
+    "time"
+)
+
+// TTLSet is a set whose items expire after a fixed duration since they were added.
+//
+// The expiration is lazy, there's no background goroutine: the reading methods
+// treat expired items as absent, and the expired items are physically removed
+// by Add and Sweep. As all the items share the same ttl, they expire in the order
+// they were added, so Add removes the expired items incrementally in amortized O(1),
+// which keeps the set from growing without bound if items keep being added.
+// Call Sweep to release the expired items if the set is no longer added to.
+//
+// 元素项在添加后经过固定时长自动过期的集合.
+// 过期处理为惰性方式, 没有后台goroutine: 读取方法将过期的元素项视为不存在,
+// 过期的元素项由Add及Sweep真正删除. 由于所有元素项的过期时长相同, 元素项按照添加顺序过期,
+// 因此Add会以均摊O(1)的代价增量删除过期的元素项, 在持续添加元素项时集合不会无限增长.
+// 如果集合不再添加元素项, 可以调用Sweep释放过期的元素项.
+type TTLSet struct {
+    mu    *rwmutex.RWMutex
+    m     map[interface{}]time.Time // 元素项对应的过期时间
+    queue *list.List                // 按照添加顺序排列的过期记录(*ttlEntry), 用于增量删除过期元素项
+    ttl   time.Duration
+    now   func() time.Time          // 获取当前时间的函数, 默认为time.Now, 测试时可替换
+}
+
+// ttlEntry is the expiration record of an item in TTLSet.
+//
+// TTLSet中元素项的过期记录.
+type ttlEntry struct {
+    item   interface{}
+    expire time.Time
+}
+
+// Create a TTL set, in which every item expires after <ttl> since it was added.
+// The param <unsafe> used to specify whether using array with un-concurrent-safety,
+// which is false in default, means concurrent-safe in default.
+//
+// 创建一个空的过期集合对象，元素项在添加ttl时长后过期，参数unsafe用于指定是否用于非并发安全场景，默认为false，表示并发安全。
+func NewTTLSet(ttl time.Duration, unsafe...bool) *TTLSet {
+    return &TTLSet{
+        m     : make(map[interface{}]time.Time),
+        mu    : rwmutex.New(unsafe...),
+        queue : list.New(),
+        ttl   : ttl,
+        now   : time.Now,
+    }
+}
+
+// TTL returns the duration after which the items expire.
+//
+// 获得元素项的过期时长.
+func (set *TTLSet) TTL() time.Duration {
+    return set.ttl
+}
+
+// Add one or multiple items to the set, and removes the expired items from the set.
+// Adding an existing item refreshes its expiration time.
+//
+// 添加元素项到集合中(支持多个), 并删除集合中过期的元素项. 添加已存在的元素项将刷新其过期时间.
+func (set *TTLSet) Add(item...interface{}) *TTLSet {
+    now    := set.now()
+    expire := now.Add(set.ttl)
+    set.mu.Lock()
+    set.sweep(now)
+    for _, v := range item {
+        set.m[v] = expire
+        set.queue.PushBack(&ttlEntry{item : v, expire : expire})
+    }
+    set.mu.Unlock()
+    return set
+}
+
+// Check whether the set contains <item>, the expired item is treated as absent.
+//
+// 键是否存在(过期的元素项视为不存在).
+func (set *TTLSet) Contains(item interface{}) bool {
+    now := set.now()
+    set.mu.RLock()
+    expire, exists := set.m[item]
+    set.mu.RUnlock()
+    return exists && now.Before(expire)
+}
+
+// Remove one or multiple items from the set.
+//
+// 从集合中删除元素项(支持多个).
+func (set *TTLSet) Remove(item...interface{}) *TTLSet {
+    set.mu.Lock()
+    for _, v := range item {
+        delete(set.m, v)
+    }
+    set.mu.Unlock()
+    return set
+}
+
+// Get size of the set, the expired items are not counted.
+// Note that it scans all the items to skip the expired ones, which costs O(n).
+//
+// 获得集合大小(不包含过期的元素项), 注意需要遍历所有元素项以排除过期的元素项, 时间复杂度为O(n)。
+func (set *TTLSet) Size() int {
+    now  := set.now()
+    size := 0
+    set.mu.RLock()
+    for _, expire := range set.m {
+        if now.Before(expire) {
+            size++
+        }
+    }
+    set.mu.RUnlock()
+    return size
+}
+
+// Clear the set.
+//
+// 清空集合。
+func (set *TTLSet) Clear() *TTLSet {
+    set.mu.Lock()
+    set.m = make(map[interface{}]time.Time)
+    set.queue.Init()
+    set.mu.Unlock()
+    return set
+}
+
+// Get the copy of unexpired items from set as slice.
+//
+// 获得集合中未过期的元素项列表.
+func (set *TTLSet) Slice() []interface{} {
+    now := set.now()
+    set.mu.RLock()
+    ret := make([]interface{}, 0, len(set.m))
+    for k, expire := range set.m {
+        if now.Before(expire) {
+            ret = append(ret, k)
+        }
+    }
+    set.mu.RUnlock()
+    return ret
+}
+
+// Sweep removes all the expired items from the set, and returns the count of removed items.
+//
+// 删除集合中所有过期的元素项, 并返回删除的元素项数量.
+func (set *TTLSet) Sweep() int {
+    set.mu.Lock()
+    count := set.sweep(set.now())
+    set.mu.Unlock()
+    return count
+}
+
+// sweep removes the items expired at <now> by popping the expired records from the front of the queue,
+// and returns the count of removed items. It should be called within the write lock.
+// The records of the items which are refreshed or removed after being recorded are just discarded.
+//
+// 从队列头部依次弹出在now时刻已过期的记录并删除对应的元素项, 返回删除的元素项数量, 需要在写锁内调用.
+// 记录之后被刷新或者删除的元素项的记录直接丢弃.
+func (set *TTLSet) sweep(now time.Time) int {
+    count := 0
+    for e := set.queue.Front(); e != nil; e = set.queue.Front() {
+        entry := e.Value.(*ttlEntry)
+        if now.Before(entry.expire) {
+            break
+        }
+        set.queue.Remove(e)
+        if expire, ok := set.m[entry.item]; ok && expire.Equal(entry.expire) {
+            delete(set.m, entry.item)
+            count++
+        }
+    }
+    return count
+}
@@ -20,6 +20,7 @@ var strs       = gset.NewStringSet()
 var intsUnsafe = gset.NewIntSet(true)
 var itfsUnsafe = gset.NewSet(true)
 var strsUnsafe = gset.NewStringSet(true)
+var shards     = gset.NewShardedSet(32)
 
 func Benchmark_IntSet_Add(b *testing.B) {
     for i := 0; i < b.N; i++ {
@@ -127,4 +128,160 @@ func Benchmark_Unsafe_StringSet_Remove(b *testing.B) {
     for i := 0; i < b.N; i++ {
         strsUnsafe.Remove(strconv.Itoa(i))
     }
+}
+
+func Benchmark_ShardedSet_AddParallel(b *testing.B) {
+    b.RunParallel(func(pb *testing.PB) {
+        i := 0
+        for pb.Next() {
+            shards.Add(i)
+            i++
+        }
+    })
+}
+
+func Benchmark_Set_AddParallel(b *testing.B) {
+    b.RunParallel(func(pb *testing.PB) {
+        i := 0
+        for pb.Next() {
+            itfs.Add(i)
+            i++
+        }
+    })
+}
+
+// newBenchSet returns a set containing the ints in range [from, from + n).
+func newBenchSet(from, n int) *gset.Set {
+    set := gset.NewSet()
+    for i := from; i < from + n; i++ {
+        set.Add(i)
+    }
+    return set
+}
+
+// The empty operand benchmarks below are paired with the non-empty ones to show the fast path.
+func Benchmark_Set_Intersect_EmptyReceiver(b *testing.B) {
+    empty := gset.NewSet()
+    full  := newBenchSet(0, 1000)
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        empty.Intersect(full)
+    }
+}
+
+func Benchmark_Set_Intersect_EmptyOther(b *testing.B) {
+    empty := gset.NewSet()
+    full  := newBenchSet(0, 1000)
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        full.Intersect(empty)
+    }
+}
+
+func Benchmark_Set_Intersect_NonEmpty(b *testing.B) {
+    full  := newBenchSet(0, 1000)
+    other := newBenchSet(500, 1000)
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        full.Intersect(other)
+    }
+}
+
+func Benchmark_Set_Intersect_Skewed(b *testing.B) {
+    large  := gset.NewSet()
+    medium := gset.NewSet()
+    small  := gset.NewSet()
+    for i := 0; i < 100000; i++ {
+        large.Add(i)
+        if i % 10 == 0 {
+            medium.Add(i)
+        }
+        if i % 1000 == 0 {
+            small.Add(i)
+        }
+    }
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        large.Intersect(medium, small)
+    }
+}
+
+// Baseline of Benchmark_Set_Intersect_Skewed: the naive intersection which iterates the largest set
+// and probes the maps of the others directly, holding the read lock of each set once.
+func Benchmark_Set_Intersect_Skewed_Naive(b *testing.B) {
+    large  := gset.NewSet()
+    medium := gset.NewSet()
+    small  := gset.NewSet()
+    for i := 0; i < 100000; i++ {
+        large.Add(i)
+        if i % 10 == 0 {
+            medium.Add(i)
+        }
+        if i % 1000 == 0 {
+            small.Add(i)
+        }
+    }
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        large.RLockFunc(func(lm map[interface{}]struct{}) {
+            medium.RLockFunc(func(mm map[interface{}]struct{}) {
+                small.RLockFunc(func(sm map[interface{}]struct{}) {
+                    result := make(map[interface{}]struct{})
+                    for k := range lm {
+                        if _, ok := mm[k]; !ok {
+                            continue
+                        }
+                        if _, ok := sm[k]; ok {
+                            result[k] = struct{}{}
+                        }
+                    }
+                })
+            })
+        })
+    }
+}
+
+func Benchmark_Set_Diff_EmptyReceiver(b *testing.B) {
+    empty := gset.NewSet()
+    full  := newBenchSet(0, 1000)
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        empty.Diff(full, full, full)
+    }
+}
+
+func Benchmark_Set_Diff_NonEmptyReceiver(b *testing.B) {
+    full  := newBenchSet(0, 1000)
+    other := newBenchSet(500, 1000)
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        full.Diff(other, other, other)
+    }
+}
+
+func Benchmark_Set_Diff_EmptyOthers(b *testing.B) {
+    empty := gset.NewSet()
+    full  := newBenchSet(0, 1000)
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        full.Diff(empty, empty, empty)
+    }
+}
+
+func Benchmark_Set_Union_EmptyOthers(b *testing.B) {
+    empty := gset.NewSet()
+    full  := newBenchSet(0, 1000)
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        full.Union(empty, empty, empty)
+    }
+}
+
+func Benchmark_Set_Union_NonEmptyOthers(b *testing.B) {
+    full  := newBenchSet(0, 1000)
+    other := newBenchSet(500, 1000)
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        full.Union(other, other, other)
+    }
 }
\ No newline at end of file
@@ -0,0 +1,14 @@
+// Copyright 2019 gf Author(https://github.com/gogf/gf). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gset
+
+import "time"
+
+// SetClock replaces the function getting the current time of the set, which is for testing only.
+func (set *TTLSet) SetClock(now func() time.Time) {
+    set.now = now
+}
@@ -0,0 +1,70 @@
+// Copyright 2019 gf Author(https://github.com/gogf/gf). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// go test *.go
+
+package gset_test
+
+import (
+    "github.com/gogf/gf/g/container/gset"
+    "github.com/gogf/gf/g/test/gtest"
+    "sync"
+    "testing"
+)
+
+func TestBloomSet_Basic(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewBloomSet(0, 0)
+        gtest.Assert(s.MayContain("a"), false)
+        s.Add("a", 1)
+        gtest.Assert(s.MayContain("a"), true)
+        gtest.Assert(s.MayContain(1), true)
+        gtest.Assert(s.MayContain("1"), false)
+        s.Clear()
+        gtest.Assert(s.MayContain("a"), false)
+    })
+}
+
+func TestBloomSet_FalsePositive(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewBloomSet(100000, 7, true)
+        for i := 0; i < 10000; i++ {
+            s.Add(i)
+        }
+        for i := 0; i < 10000; i++ {
+            gtest.Assert(s.MayContain(i), true)
+        }
+        positives := 0
+        for i := 10000; i < 20000; i++ {
+            if s.MayContain(i) {
+                positives++
+            }
+        }
+        // 理论误判率约为0.8%.
+        gtest.Assert(positives < 300, true)
+    })
+}
+
+func TestBloomSet_Concurrent(t *testing.T) {
+    gtest.Case(t, func() {
+        s  := gset.NewBloomSet(1 << 16, 5)
+        wg := sync.WaitGroup{}
+        for i := 0; i < 10; i++ {
+            wg.Add(1)
+            go func(i int) {
+                defer wg.Done()
+                for j := 0; j < 100; j++ {
+                    s.Add(i*100 + j)
+                    s.MayContain(j)
+                }
+            }(i)
+        }
+        wg.Wait()
+        for i := 0; i < 1000; i++ {
+            gtest.Assert(s.MayContain(i), true)
+        }
+    })
+}
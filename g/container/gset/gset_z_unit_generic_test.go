@@ -0,0 +1,104 @@
+// Copyright 2019 gf Author(https://github.com/gogf/gf). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+//go:build go1.18
+// +build go1.18
+
+// go test *.go
+
+package gset_test
+
+import (
+    "github.com/gogf/gf/g/container/gset"
+    "github.com/gogf/gf/g/test/gtest"
+    "testing"
+)
+
+func TestGSet_Basic(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewGSet[int]()
+        s.Add(1).Add(1).Add(2)
+        s.Add([]int{3,4}...)
+        gtest.Assert(s.Size(), 4)
+        gtest.AssertIN(1, s.Slice())
+        gtest.AssertIN(4, s.Slice())
+        gtest.Assert(s.Contains(4), true)
+        gtest.Assert(s.Contains(5), false)
+        s.Remove(1, 2)
+        gtest.Assert(s.Size(), 2)
+        s.Clear()
+        gtest.Assert(s.Size(), 0)
+    })
+}
+
+func TestGSet_Iterator(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewGSet[string]()
+        s.Add("a", "b", "c")
+        n := 0
+        s.Iterator(func(v string) bool {
+            n++
+            return true
+        })
+        gtest.Assert(n, 3)
+    })
+}
+
+func TestGSet_Operations(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewGSet[int]()
+        s2 := gset.NewGSet[int]()
+        s1.Add(1, 2, 3)
+        s2.Add(3, 4, 5)
+
+        union := s1.Union(s2)
+        gtest.Assert(union.Size(), 5)
+        gtest.Assert(union.Equal(s2.Union(s1)), true)
+
+        diff := s1.Diff(s2)
+        gtest.Assert(diff.Size(), 2)
+        gtest.Assert(diff.Contains(1), true)
+        gtest.Assert(diff.Contains(3), false)
+
+        intersect := s1.Intersect(s2)
+        gtest.Assert(intersect.Size(), 1)
+        gtest.Assert(intersect.Contains(3), true)
+    })
+}
+
+func TestGSet_Diff_Multiple(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewGSet[int]().Add(1, 2, 3, 4, 5)
+        s2 := gset.NewGSet[int]().Add(1, 2)
+        s3 := gset.NewGSet[int]().Add(5, 6)
+        s4 := s1.Diff(s2, s3)
+        gtest.Assert(s4.Size(), 2)
+        gtest.Assert(s4.Contains(3), true)
+        gtest.Assert(s4.Contains(4), true)
+        gtest.Assert(s1.Diff(s2, gset.NewGSet[int]()).Size(), 3)
+        gtest.Assert(s1.Diff().Size(), 5)
+        gtest.Assert(s1.Diff(s2, s1).Size(), 0)
+    })
+}
+
+func TestGSet_Intersect_Multiple(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewGSet[int]().Add(1, 2)
+        s2 := gset.NewGSet[int]().Add(3)
+        gtest.Assert(s1.Intersect(s2).Size(), 0)
+
+        s1  = gset.NewGSet[int]().Add(1, 2, 3, 4)
+        s2  = gset.NewGSet[int]().Add(2, 3, 4, 5, 6)
+        s3 := gset.NewGSet[int]().Add(3, 4, 7)
+        gtest.Assert(s1.Intersect(s2, s3).Size(), 2)
+        gtest.Assert(s1.Intersect(s2, s3).Contains(3), true)
+        gtest.Assert(s1.Intersect(s2, s3).Contains(4), true)
+        gtest.Assert(s3.Intersect(s1, s2).Size(), 2)
+        gtest.Assert(s1.Intersect(s2, s3, gset.NewGSet[int]().Add(7)).Size(), 0)
+        gtest.Assert(s1.Intersect(s1, s2).Size(), 3)
+        gtest.Assert(s1.Intersect().Size(), 0)
+    })
+}
@@ -0,0 +1,67 @@
+// Copyright 2019 gf Author(https://github.com/gogf/gf). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// go test *.go
+
+package gset_test
+
+import (
+    "github.com/gogf/gf/g/container/gset"
+    "github.com/gogf/gf/g/test/gtest"
+    "testing"
+)
+
+type hashedUser struct {
+    Id   int
+    Name string
+}
+
+func TestHashedSet_Basic(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewWithHasher(func(v interface{}) interface{} {
+            return v.(hashedUser).Id
+        })
+        s.Add(hashedUser{1, "john"}, hashedUser{2, "smith"}).Add(hashedUser{1, "johnny"})
+        gtest.Assert(s.Size(), 2)
+        gtest.Assert(s.Contains(hashedUser{Id : 1}), true)
+        gtest.Assert(s.Contains(hashedUser{Id : 3}), false)
+
+        v, ok := s.Get(hashedUser{Id : 1})
+        gtest.Assert(ok, true)
+        gtest.Assert(v.(hashedUser).Name, "john")
+        _, ok = s.Get(hashedUser{Id : 3})
+        gtest.Assert(ok, false)
+        gtest.AssertIN(hashedUser{2, "smith"}, s.Slice())
+
+        s.Remove(hashedUser{Id : 1}, hashedUser{Id : 3})
+        gtest.Assert(s.Size(), 1)
+        gtest.Assert(s.Contains(hashedUser{Id : 1}), false)
+
+        s.Clear()
+        gtest.Assert(s.Size(), 0)
+    })
+}
+
+func TestHashedSet_Iterator(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewWithHasher(func(v interface{}) interface{} {
+            return v.(hashedUser).Id
+        }, true)
+        s.Add(hashedUser{1, "john"}, hashedUser{2, "smith"})
+        names := gset.NewSet()
+        s.Iterator(func(v interface{}) bool {
+            names.Add(v.(hashedUser).Name)
+            return true
+        })
+        gtest.Assert(names.Equal(gset.NewFrom([]string{"john", "smith"})), true)
+        count := 0
+        s.Iterator(func(v interface{}) bool {
+            count++
+            return false
+        })
+        gtest.Assert(count, 1)
+    })
+}
@@ -0,0 +1,59 @@
+// Copyright 2019 gf Author(https://github.com/gogf/gf). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// go test *.go
+
+package gset_test
+
+import (
+    "github.com/gogf/gf/g/container/gset"
+    "github.com/gogf/gf/g/test/gtest"
+    "math"
+    "testing"
+)
+
+// hllWithin checks whether <estimate> is within the relative error <rate> of <actual>.
+func hllWithin(estimate, actual int, rate float64) bool {
+    return math.Abs(float64(estimate - actual)) <= float64(actual) * rate
+}
+
+func TestHLLSet_Basic(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewHLLSet(0)
+        gtest.Assert(s.Precision(), 14)
+        gtest.Assert(s.EstimateSize(), 0)
+        s.Add("a", "b", "a").Add(1, "1")
+        gtest.Assert(s.EstimateSize(), 4)
+
+        for i := 0; i < 100000; i++ {
+            s.Add(i)
+        }
+        gtest.Assert(hllWithin(s.EstimateSize(), 100003, 0.03), true)
+
+        s.Clear()
+        gtest.Assert(s.EstimateSize(), 0)
+    })
+}
+
+func TestHLLSet_Merge(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewHLLSet(12)
+        s2 := gset.NewHLLSet(12, true)
+        for i := 0; i < 60000; i++ {
+            s1.Add(i)
+        }
+        for i := 40000; i < 100000; i++ {
+            s2.Add(i)
+        }
+        gtest.Assert(s1.Merge(s2, s1), nil)
+        gtest.Assert(hllWithin(s1.EstimateSize(), 100000, 0.05), true)
+        gtest.Assert(hllWithin(s2.EstimateSize(), 60000, 0.05), true)
+
+        size := s1.EstimateSize()
+        gtest.AssertNE(s1.Merge(gset.NewHLLSet(10).Add(-1)), nil)
+        gtest.Assert(s1.EstimateSize(), size)
+    })
+}
@@ -9,6 +9,7 @@
 package gset_test
 
 import (
+    "encoding/json"
     "github.com/gogf/gf/g/container/garray"
     "github.com/gogf/gf/g/container/gset"
     "github.com/gogf/gf/g/test/gtest"
@@ -35,6 +36,72 @@ func TestIntSet_Basic(t *testing.T) {
     })
 }
 
+func TestNewIntSetFrom(t *testing.T) {
+    gtest.Case(t, func() {
+        items := []int{1, 2, 2, 3}
+        s     := gset.NewIntSetFrom(items)
+        gtest.Assert(s.Size(), 3)
+        gtest.Assert(s.Equal(gset.NewIntSet().Add(1, 2, 3)), true)
+        items[0] = 4
+        gtest.Assert(s.Contains(4), false)
+        gtest.Assert(gset.NewIntSetFrom(nil, true).Size(), 0)
+    })
+}
+
+func TestIntSet_Remove(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewIntSet()
+        s.Add(1, 2, 3, 4)
+        s.Remove(1, 3, 5)
+        gtest.Assert(s.Size(), 2)
+        gtest.Assert(s.Contains(2), true)
+        gtest.Assert(s.Contains(4), true)
+    })
+}
+
+func TestIntSet_Pop(t *testing.T) {
+    gtest.Case(t, func() {
+        s      := gset.NewIntSetFrom([]int{1, 2, 3})
+        popped := gset.NewIntSet()
+        for {
+            v, ok := s.Pop()
+            if !ok {
+                break
+            }
+            gtest.Assert(popped.Contains(v), false)
+            popped.Add(v)
+        }
+        gtest.Assert(s.Size(), 0)
+        gtest.Assert(popped.Equal(gset.NewIntSetFrom([]int{1, 2, 3})), true)
+        v, ok := s.Pop()
+        gtest.Assert(v, 0)
+        gtest.Assert(ok, false)
+    })
+}
+
+func TestIntSet_AddIfNotExist(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewIntSet()
+        s.Add(1)
+        gtest.Assert(s.AddIfNotExist(1), false)
+        gtest.Assert(s.AddIfNotExist(2), true)
+        gtest.Assert(s.AddIfNotExist(2), false)
+        gtest.Assert(s.Size(), 2)
+    })
+}
+
+func TestIntSet_AddIfNotExistFunc(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewIntSet()
+        s.Add(1)
+        gtest.Assert(s.AddIfNotExistFunc(1, func() bool { return true }), false)
+        gtest.Assert(s.AddIfNotExistFunc(2, func() bool { return false }), false)
+        gtest.Assert(s.Contains(2), false)
+        gtest.Assert(s.AddIfNotExistFunc(2, func() bool { return true }), true)
+        gtest.Assert(s.Contains(2), true)
+    })
+}
+
 func TestIntSet_Iterator(t *testing.T) {
     gtest.Case(t, func() {
         s := gset.NewIntSet()
@@ -103,6 +170,18 @@ func TestIntSet_IsSubsetOf(t *testing.T) {
     })
 }
 
+func TestIntSet_IsSupersetOf(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewIntSet()
+        s2 := gset.NewIntSet()
+        s1.Add(1).Add(2)
+        s2.Add(1).Add(2).Add(3)
+        gtest.Assert(s2.IsSupersetOf(s1), true)
+        gtest.Assert(s1.IsSupersetOf(s2), false)
+        gtest.Assert(s1.IsSupersetOf(s1), true)
+    })
+}
+
 func TestIntSet_Union(t *testing.T) {
     gtest.Case(t, func() {
         s1 := gset.NewIntSet()
@@ -117,6 +196,27 @@ func TestIntSet_Union(t *testing.T) {
     })
 }
 
+func TestIntSet_Union_Multiple(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewIntSet()
+        s2 := gset.NewIntSet()
+        s3 := gset.NewIntSet()
+        s4 := gset.NewIntSet()
+        s1.Add(1).Add(2)
+        s2.Add(2).Add(3)
+        s3.Add(4)
+        s4.Add(5)
+        s := s1.Union(s2, s1, s3, s4)
+        gtest.Assert(s.Size(), 5)
+        gtest.Assert(s.Contains(1), true)
+        gtest.Assert(s.Contains(3), true)
+        gtest.Assert(s.Contains(4), true)
+        gtest.Assert(s.Contains(5), true)
+        gtest.Assert(s1.Union().Equal(s1), true)
+        gtest.Assert(s1.Union(s1).Equal(s1), true)
+    })
+}
+
 func TestIntSet_Diff(t *testing.T) {
     gtest.Case(t, func() {
         s1 := gset.NewIntSet()
@@ -131,6 +231,21 @@ func TestIntSet_Diff(t *testing.T) {
     })
 }
 
+func TestIntSet_Diff_Multiple(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewIntSetFrom([]int{1, 2, 3, 4, 5})
+        s2 := gset.NewIntSetFrom([]int{1, 2})
+        s3 := gset.NewIntSetFrom([]int{5, 6})
+        s4 := s1.Diff(s2, s3)
+        gtest.Assert(s4.Size(), 2)
+        gtest.Assert(s4.Contains(3), true)
+        gtest.Assert(s4.Contains(4), true)
+        gtest.Assert(s1.Diff(s2, gset.NewIntSet()).Size(), 3)
+        gtest.Assert(s1.Diff().Size(), 5)
+        gtest.Assert(s1.Diff(s2, s1).Size(), 0)
+    })
+}
+
 func TestIntSet_Intersect(t *testing.T) {
     gtest.Case(t, func() {
         s1 := gset.NewIntSet()
@@ -145,6 +260,25 @@ func TestIntSet_Intersect(t *testing.T) {
     })
 }
 
+func TestIntSet_Intersect_Multiple(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewIntSetFrom([]int{1, 2})
+        s2 := gset.NewIntSetFrom([]int{3})
+        gtest.Assert(s1.Intersect(s2).Size(), 0)
+
+        s1  = gset.NewIntSetFrom([]int{1, 2, 3, 4})
+        s2  = gset.NewIntSetFrom([]int{2, 3, 4, 5, 6})
+        s3 := gset.NewIntSetFrom([]int{3, 4, 7})
+        gtest.Assert(s1.Intersect(s2, s3).Size(), 2)
+        gtest.Assert(s1.Intersect(s2, s3).Contains(3), true)
+        gtest.Assert(s1.Intersect(s2, s3).Contains(4), true)
+        gtest.Assert(s3.Intersect(s1, s2).Size(), 2)
+        gtest.Assert(s1.Intersect(s2, s3, gset.NewIntSetFrom([]int{7})).Size(), 0)
+        gtest.Assert(s1.Intersect(s1, s2).Size(), 3)
+        gtest.Assert(s1.Intersect().Size(), 0)
+    })
+}
+
 func TestIntSet_Complement(t *testing.T) {
     gtest.Case(t, func() {
         s1 := gset.NewIntSet()
@@ -157,4 +291,124 @@ func TestIntSet_Complement(t *testing.T) {
         gtest.Assert(s3.Contains(4), true)
         gtest.Assert(s3.Contains(5), true)
     })
-}
\ No newline at end of file
+}
+
+func TestIntSet_Json(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewIntSet()
+        b, err := json.Marshal(s)
+        gtest.Assert(err, nil)
+        gtest.Assert(string(b), "[]")
+
+        s.Add(1, 2, 3)
+        b, err = json.Marshal(s)
+        gtest.Assert(err, nil)
+        a := make([]int, 0)
+        gtest.Assert(json.Unmarshal(b, &a), nil)
+        gtest.Assert(len(a), 3)
+        gtest.AssertIN(1, a)
+        gtest.AssertIN(2, a)
+        gtest.AssertIN(3, a)
+    })
+}
+
+func TestIntSet_UnmarshalJSON(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewIntSet()
+        s.Add(9)
+        gtest.Assert(json.Unmarshal([]byte(`[1,2,3]`), s), nil)
+        gtest.Assert(s.Size(), 3)
+        gtest.Assert(s.Contains(1), true)
+        gtest.Assert(s.Contains(9), false)
+
+        gtest.Assert(json.Unmarshal([]byte(`null`), s), nil)
+        gtest.Assert(s.Size(), 0)
+
+        gtest.AssertNE(json.Unmarshal([]byte(`["a"]`), s), nil)
+    })
+}
+
+func TestIntSet_Merge(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewIntSet()
+        s2 := gset.NewIntSet()
+        s3 := gset.NewIntSet()
+        s1.Add(1).Add(2)
+        s2.Add(2).Add(3)
+        s3.Add(4)
+        gtest.Assert(s1.Merge(s2, s3, s1) == s1, true)
+        gtest.Assert(s1.Size(), 4)
+        gtest.Assert(s1.Contains(1), true)
+        gtest.Assert(s1.Contains(3), true)
+        gtest.Assert(s1.Contains(4), true)
+        gtest.Assert(s2.Size(), 2)
+    })
+}
+
+func TestIntSet_Clone(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewIntSet(true)
+        s1.Add(1).Add(2)
+        s2 := s1.Clone()
+        gtest.Assert(s2.Equal(s1), true)
+        s2.Add(3)
+        gtest.Assert(s1.Contains(3), false)
+        gtest.Assert(s2.Size(), 3)
+    })
+}
+
+func TestIntSet_Sum(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewIntSet()
+        gtest.Assert(s.Sum(), 0)
+        s.Add(1, 2, 3)
+        gtest.Assert(s.Sum(), 6)
+    })
+}
+
+func TestIntSet_Walk(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewIntSet()
+        s.Add(1, 2, 3)
+        s.Walk(func(item int) int {
+            return item+10
+        })
+        gtest.Assert(s.Size(), 3)
+        gtest.Assert(s.Contains(1), false)
+        gtest.Assert(s.Contains(11), true)
+        gtest.Assert(s.Contains(12), true)
+        gtest.Assert(s.Contains(13), true)
+    })
+}
+
+func TestIntSet_Join(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewIntSet()
+        s.Add(1, 2)
+        for _, glue := range []string{"-", "|", ", ", ""} {
+            gtest.AssertIN(s.Join(glue), []string{"1" + glue + "2", "2" + glue + "1"})
+        }
+        gtest.AssertIN(s.String(), []string{"1,2", "2,1"})
+    })
+}
+
+func TestIntSet_LockOrder(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewIntSetFrom([]int{1, 2, 3})
+        s2 := gset.NewIntSetFrom([]int{2, 3, 4})
+        assertNoDeadlock(t, func(swapped bool, j int) {
+            a, b := s1, s2
+            if swapped {
+                a, b = s2, s1
+            }
+            a.Equal(b)
+            a.Union(b)
+            a.Diff(b)
+            a.Intersect(b)
+            a.Complement(b)
+            a.Merge(b)
+            a.Add(j)
+            a.Remove(j)
+        })
+    })
+}
@@ -0,0 +1,48 @@
+// Copyright 2019 gf Author(https://github.com/gogf/gf). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// go test *.go
+
+package gset_test
+
+import (
+    "github.com/gogf/gf/g/container/gset"
+    "github.com/gogf/gf/g/test/gtest"
+    "testing"
+)
+
+func TestLinkedSet_Basic(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewLinkedSet()
+        s.Add(3).Add(1).Add(3).Add(2)
+        s.Add([]interface{}{5, 4}...)
+        gtest.Assert(s.Size(), 5)
+        gtest.Assert(s.Slice(), []interface{}{3, 1, 2, 5, 4})
+        gtest.Assert(s.Contains(4), true)
+        gtest.Assert(s.Contains(6), false)
+        s.Remove(1, 5, 6)
+        gtest.Assert(s.Slice(), []interface{}{3, 2, 4})
+        s.Add(1)
+        gtest.Assert(s.Join("|"), "3|2|4|1")
+        gtest.Assert(s.String(), "3,2,4,1")
+        s.Clear()
+        gtest.Assert(s.Size(), 0)
+        gtest.Assert(len(s.Slice()), 0)
+    })
+}
+
+func TestLinkedSet_Iterator(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewLinkedSet()
+        s.Add("c", "a", "b")
+        a := make([]interface{}, 0)
+        s.Iterator(func(v interface{}) bool {
+            a = append(a, v)
+            return len(a) < 2
+        })
+        gtest.Assert(a, []interface{}{"c", "a"})
+    })
+}
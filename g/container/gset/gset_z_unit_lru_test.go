@@ -0,0 +1,48 @@
+// Copyright 2019 gf Author(https://github.com/gogf/gf). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// go test *.go
+
+package gset_test
+
+import (
+    "github.com/gogf/gf/g/container/gset"
+    "github.com/gogf/gf/g/test/gtest"
+    "testing"
+)
+
+func TestLRUSet_Basic(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewLRUSet(3)
+        gtest.Assert(s.Cap(), 3)
+        s.Add(1, 2, 3)
+        gtest.Assert(s.Size(), 3)
+        s.Add(4)
+        gtest.Assert(s.Size(), 3)
+        gtest.Assert(s.Contains(1), false)
+        gtest.Assert(s.Slice(), []interface{}{2, 3, 4})
+
+        gtest.Assert(s.Touch(2), true)
+        gtest.Assert(s.Touch(1), false)
+        s.Add(5)
+        gtest.Assert(s.Slice(), []interface{}{4, 2, 5})
+
+        s.Add(4)
+        s.Add(6)
+        gtest.Assert(s.Slice(), []interface{}{5, 4, 6})
+
+        s.Remove(4, 7)
+        gtest.Assert(s.Size(), 2)
+        s.Clear()
+        gtest.Assert(s.Size(), 0)
+    })
+    gtest.Case(t, func() {
+        s := gset.NewLRUSet(0, true)
+        gtest.Assert(s.Cap(), 1)
+        s.Add(1, 2)
+        gtest.Assert(s.Slice(), []interface{}{2})
+    })
+}
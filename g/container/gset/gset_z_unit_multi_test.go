@@ -0,0 +1,52 @@
+// Copyright 2019 gf Author(https://github.com/gogf/gf). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// go test *.go
+
+package gset_test
+
+import (
+    "github.com/gogf/gf/g/container/gset"
+    "github.com/gogf/gf/g/test/gtest"
+    "testing"
+)
+
+func TestMultiSet_Basic(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewMultiSet()
+        s.Add(1, 1, 2).Add(1)
+        gtest.Assert(s.Size(), 4)
+        gtest.Assert(s.Distinct(), 2)
+        gtest.Assert(s.Count(1), 3)
+        gtest.Assert(s.Count(2), 1)
+        gtest.Assert(s.Count(3), 0)
+        gtest.Assert(s.Contains(2), true)
+        gtest.Assert(len(s.Slice()), 2)
+
+        s.Remove(1, 2, 3)
+        gtest.Assert(s.Size(), 2)
+        gtest.Assert(s.Count(1), 2)
+        gtest.Assert(s.Contains(2), false)
+        gtest.Assert(s.Distinct(), 1)
+
+        s.Clear()
+        gtest.Assert(s.Size(), 0)
+        gtest.Assert(s.Distinct(), 0)
+    })
+}
+
+func TestMultiSet_Iterator(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewMultiSet()
+        s.Add("a", "b", "b")
+        total := 0
+        s.Iterator(func(v interface{}, count int) bool {
+            total += count
+            return true
+        })
+        gtest.Assert(total, 3)
+    })
+}
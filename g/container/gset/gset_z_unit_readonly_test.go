@@ -0,0 +1,49 @@
+// Copyright 2019 gf Author(https://github.com/gogf/gf). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// go test *.go
+
+package gset_test
+
+import (
+    "github.com/gogf/gf/g/container/gset"
+    "github.com/gogf/gf/g/test/gtest"
+    "testing"
+)
+
+func TestReadonlySet_Basic(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(1, 2, 3)
+        r := s.Readonly()
+        gtest.Assert(r.Size(), 3)
+        gtest.Assert(r.Contains(1), true)
+        gtest.Assert(r.Contains(4), false)
+        gtest.AssertIN(2, r.Slice())
+
+        s.Add(4)
+        gtest.Assert(r.Size(), 4)
+        gtest.Assert(r.Contains(4), true)
+
+        c := r.Clone()
+        c.Add(5)
+        gtest.Assert(r.Contains(5), false)
+        gtest.Assert(r.Equal(s), true)
+        gtest.Assert(r.IsSubsetOf(c), true)
+        gtest.Assert(r.IsSupersetOf(c), false)
+        gtest.Assert(r.Union(c).Size(), 5)
+        gtest.Assert(r.Intersect(c).Size(), 4)
+        gtest.Assert(r.Diff(c).Size(), 0)
+        gtest.Assert(r.Complement(c).Slice(), []interface{}{5})
+
+        n := 0
+        r.Iterator(func(v interface{}) bool {
+            n++
+            return true
+        })
+        gtest.Assert(n, 4)
+    })
+}
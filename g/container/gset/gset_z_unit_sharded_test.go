@@ -0,0 +1,72 @@
+// Copyright 2019 gf Author(https://github.com/gogf/gf). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// go test *.go
+
+package gset_test
+
+import (
+    "github.com/gogf/gf/g/container/gset"
+    "github.com/gogf/gf/g/test/gtest"
+    "sync"
+    "testing"
+)
+
+func TestShardedSet_Basic(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewShardedSet(4)
+        s.Add(1, 2, 2, "a", "b")
+        gtest.Assert(s.Size(), 4)
+        gtest.Assert(s.Contains(1), true)
+        gtest.Assert(s.Contains("a"), true)
+        gtest.Assert(s.Contains(3), false)
+        gtest.Assert(len(s.Slice()), 4)
+        gtest.Assert(s.Set().Equal(gset.NewFrom([]interface{}{1, 2, "a", "b"})), true)
+        s.Remove(1, "a")
+        gtest.Assert(s.Size(), 2)
+        s.Clear()
+        gtest.Assert(s.Size(), 0)
+    })
+}
+
+func TestShardedSet_Iterator(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewShardedSet(0)
+        for i := 0; i < 100; i++ {
+            s.Add(i)
+        }
+        n := 0
+        s.Iterator(func(v interface{}) bool {
+            n++
+            return true
+        })
+        gtest.Assert(n, 100)
+        n = 0
+        s.Iterator(func(v interface{}) bool {
+            n++
+            return n < 10
+        })
+        gtest.Assert(n, 10)
+    })
+}
+
+func TestShardedSet_Concurrent(t *testing.T) {
+    gtest.Case(t, func() {
+        s  := gset.NewShardedSet(8)
+        wg := sync.WaitGroup{}
+        for i := 0; i < 10; i++ {
+            wg.Add(1)
+            go func(i int) {
+                defer wg.Done()
+                for j := 0; j < 100; j++ {
+                    s.Add(i*100 + j)
+                }
+            }(i)
+        }
+        wg.Wait()
+        gtest.Assert(s.Size(), 1000)
+    })
+}
@@ -9,6 +9,7 @@
 package gset_test
 
 import (
+    "encoding/json"
     "github.com/gogf/gf/g/container/garray"
     "github.com/gogf/gf/g/container/gset"
     "github.com/gogf/gf/g/test/gtest"
@@ -35,6 +36,71 @@ func TestStringSet_Basic(t *testing.T) {
     })
 }
 
+func TestStringSet_ContainsI(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewStrSet()
+        s.Add("abc", "Def")
+        gtest.Assert(s.ContainsI("abc"), true)
+        gtest.Assert(s.ContainsI("ABC"), true)
+        gtest.Assert(s.ContainsI("dEF"), true)
+        gtest.Assert(s.ContainsI("ghi"), false)
+    })
+}
+
+func TestStringSet_Remove(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewStrSet()
+        s.Add("1", "2", "3", "4")
+        s.Remove("1", "3", "5")
+        gtest.Assert(s.Size(), 2)
+        gtest.Assert(s.Contains("2"), true)
+        gtest.Assert(s.Contains("4"), true)
+    })
+}
+
+func TestStrSet_Pop(t *testing.T) {
+    gtest.Case(t, func() {
+        s      := gset.NewStrSetFrom([]string{"a", "b", "c"})
+        popped := gset.NewStrSet()
+        for {
+            v, ok := s.Pop()
+            if !ok {
+                break
+            }
+            gtest.Assert(popped.Contains(v), false)
+            popped.Add(v)
+        }
+        gtest.Assert(s.Size(), 0)
+        gtest.Assert(popped.Equal(gset.NewStrSetFrom([]string{"a", "b", "c"})), true)
+        v, ok := s.Pop()
+        gtest.Assert(v, "")
+        gtest.Assert(ok, false)
+    })
+}
+
+func TestStringSet_AddIfNotExist(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewStrSet()
+        s.Add("1")
+        gtest.Assert(s.AddIfNotExist("1"), false)
+        gtest.Assert(s.AddIfNotExist("2"), true)
+        gtest.Assert(s.AddIfNotExist("2"), false)
+        gtest.Assert(s.Size(), 2)
+    })
+}
+
+func TestStringSet_AddIfNotExistFunc(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewStrSet()
+        s.Add("1")
+        gtest.Assert(s.AddIfNotExistFunc("1", func() bool { return true }), false)
+        gtest.Assert(s.AddIfNotExistFunc("2", func() bool { return false }), false)
+        gtest.Assert(s.Contains("2"), false)
+        gtest.Assert(s.AddIfNotExistFunc("2", func() bool { return true }), true)
+        gtest.Assert(s.Contains("2"), true)
+    })
+}
+
 func TestStringSet_Iterator(t *testing.T) {
     gtest.Case(t, func() {
         s := gset.NewStringSet()
@@ -103,6 +169,18 @@ func TestStringSet_IsSubsetOf(t *testing.T) {
     })
 }
 
+func TestStringSet_IsSupersetOf(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewStrSet()
+        s2 := gset.NewStrSet()
+        s1.Add("1").Add("2")
+        s2.Add("1").Add("2").Add("3")
+        gtest.Assert(s2.IsSupersetOf(s1), true)
+        gtest.Assert(s1.IsSupersetOf(s2), false)
+        gtest.Assert(s1.IsSupersetOf(s1), true)
+    })
+}
+
 func TestStringSet_Union(t *testing.T) {
     gtest.Case(t, func() {
         s1 := gset.NewStringSet()
@@ -117,6 +195,27 @@ func TestStringSet_Union(t *testing.T) {
     })
 }
 
+func TestStringSet_Union_Multiple(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewStrSet()
+        s2 := gset.NewStrSet()
+        s3 := gset.NewStrSet()
+        s4 := gset.NewStrSet()
+        s1.Add("1").Add("2")
+        s2.Add("2").Add("3")
+        s3.Add("4")
+        s4.Add("5")
+        s := s1.Union(s2, s1, s3, s4)
+        gtest.Assert(s.Size(), 5)
+        gtest.Assert(s.Contains("1"), true)
+        gtest.Assert(s.Contains("3"), true)
+        gtest.Assert(s.Contains("4"), true)
+        gtest.Assert(s.Contains("5"), true)
+        gtest.Assert(s1.Union().Equal(s1), true)
+        gtest.Assert(s1.Union(s1).Equal(s1), true)
+    })
+}
+
 func TestStringSet_Diff(t *testing.T) {
     gtest.Case(t, func() {
         s1 := gset.NewStringSet()
@@ -131,6 +230,21 @@ func TestStringSet_Diff(t *testing.T) {
     })
 }
 
+func TestStringSet_Diff_Multiple(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewStrSetFrom([]string{"1", "2", "3", "4", "5"})
+        s2 := gset.NewStrSetFrom([]string{"1", "2"})
+        s3 := gset.NewStrSetFrom([]string{"5", "6"})
+        s4 := s1.Diff(s2, s3)
+        gtest.Assert(s4.Size(), 2)
+        gtest.Assert(s4.Contains("3"), true)
+        gtest.Assert(s4.Contains("4"), true)
+        gtest.Assert(s1.Diff(s2, gset.NewStrSet()).Size(), 3)
+        gtest.Assert(s1.Diff().Size(), 5)
+        gtest.Assert(s1.Diff(s2, s1).Size(), 0)
+    })
+}
+
 func TestStringSet_Intersect(t *testing.T) {
     gtest.Case(t, func() {
         s1 := gset.NewStringSet()
@@ -145,6 +259,25 @@ func TestStringSet_Intersect(t *testing.T) {
     })
 }
 
+func TestStringSet_Intersect_Multiple(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewStrSetFrom([]string{"1", "2"})
+        s2 := gset.NewStrSetFrom([]string{"3"})
+        gtest.Assert(s1.Intersect(s2).Size(), 0)
+
+        s1  = gset.NewStrSetFrom([]string{"1", "2", "3", "4"})
+        s2  = gset.NewStrSetFrom([]string{"2", "3", "4", "5", "6"})
+        s3 := gset.NewStrSetFrom([]string{"3", "4", "7"})
+        gtest.Assert(s1.Intersect(s2, s3).Size(), 2)
+        gtest.Assert(s1.Intersect(s2, s3).Contains("3"), true)
+        gtest.Assert(s1.Intersect(s2, s3).Contains("4"), true)
+        gtest.Assert(s3.Intersect(s1, s2).Size(), 2)
+        gtest.Assert(s1.Intersect(s2, s3, gset.NewStrSetFrom([]string{"7"})).Size(), 0)
+        gtest.Assert(s1.Intersect(s1, s2).Size(), 3)
+        gtest.Assert(s1.Intersect().Size(), 0)
+    })
+}
+
 func TestStringSet_Complement(t *testing.T) {
     gtest.Case(t, func() {
         s1 := gset.NewStringSet()
@@ -157,4 +290,126 @@ func TestStringSet_Complement(t *testing.T) {
         gtest.Assert(s3.Contains("4"), true)
         gtest.Assert(s3.Contains("5"), true)
     })
+}
+
+func TestStringSet_Json(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewStringSet()
+        b, err := json.Marshal(s)
+        gtest.Assert(err, nil)
+        gtest.Assert(string(b), "[]")
+
+        s.Add("a", "b", "c")
+        b, err = json.Marshal(s)
+        gtest.Assert(err, nil)
+        a := make([]string, 0)
+        gtest.Assert(json.Unmarshal(b, &a), nil)
+        gtest.Assert(len(a), 3)
+        gtest.AssertIN("a", a)
+        gtest.AssertIN("b", a)
+        gtest.AssertIN("c", a)
+    })
+}
+
+func TestStringSet_UnmarshalJSON(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewStringSet()
+        s.Add("z")
+        gtest.Assert(json.Unmarshal([]byte(`["a","b","c"]`), s), nil)
+        gtest.Assert(s.Size(), 3)
+        gtest.Assert(s.Contains("a"), true)
+        gtest.Assert(s.Contains("z"), false)
+
+        gtest.Assert(json.Unmarshal([]byte(`null`), s), nil)
+        gtest.Assert(s.Size(), 0)
+
+        gtest.AssertNE(json.Unmarshal([]byte(`"a"`), s), nil)
+    })
+}
+
+func TestStrSet_Basic(t *testing.T) {
+    gtest.Case(t, func() {
+        var s *gset.StringSet = gset.NewStrSet()
+        s.Add("a", "b")
+        gtest.Assert(s.Size(), 2)
+        gtest.Assert(s.Contains("a"), true)
+        gtest.Assert(s.Contains("c"), false)
+        gtest.AssertIN("b", s.Slice())
+    })
+}
+
+func TestNewStrSetFrom(t *testing.T) {
+    gtest.Case(t, func() {
+        items := []string{"a", "b", "b", "c"}
+        s     := gset.NewStrSetFrom(items)
+        gtest.Assert(s.Size(), 3)
+        gtest.Assert(s.Equal(gset.NewStrSet().Add("a", "b", "c")), true)
+        items[0] = "d"
+        gtest.Assert(s.Contains("d"), false)
+        gtest.Assert(gset.NewStrSetFrom(nil, true).Size(), 0)
+    })
+}
+
+func TestStringSet_Merge(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewStrSet()
+        s2 := gset.NewStrSet()
+        s3 := gset.NewStrSet()
+        s1.Add("1").Add("2")
+        s2.Add("2").Add("3")
+        s3.Add("4")
+        gtest.Assert(s1.Merge(s2, s3, s1) == s1, true)
+        gtest.Assert(s1.Size(), 4)
+        gtest.Assert(s1.Contains("1"), true)
+        gtest.Assert(s1.Contains("3"), true)
+        gtest.Assert(s1.Contains("4"), true)
+        gtest.Assert(s2.Size(), 2)
+    })
+}
+
+func TestStringSet_Clone(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewStrSet(true)
+        s1.Add("1").Add("2")
+        s2 := s1.Clone()
+        gtest.Assert(s2.Equal(s1), true)
+        s2.Add("3")
+        gtest.Assert(s1.Contains("3"), false)
+        gtest.Assert(s2.Size(), 3)
+    })
+}
+
+func TestStringSet_Sum(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewStrSet()
+        gtest.Assert(s.Sum(), 0)
+        s.Add("1", "2", "3")
+        gtest.Assert(s.Sum(), 6)
+    })
+}
+
+func TestStringSet_Walk(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewStrSet()
+        s.Add("1", "2", "3")
+        s.Walk(func(item string) string {
+            return "x"+item
+        })
+        gtest.Assert(s.Size(), 3)
+        gtest.Assert(s.Contains("1"), false)
+        gtest.Assert(s.Contains("x1"), true)
+        gtest.Assert(s.Contains("x2"), true)
+        gtest.Assert(s.Contains("x3"), true)
+    })
+}
+
+func TestStringSet_Join(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewStrSet()
+        s.Add("a", "b")
+        for _, glue := range []string{"-", "|", ", ", ""} {
+            gtest.AssertIN(s.Join(glue), []string{"a" + glue + "b", "b" + glue + "a"})
+        }
+        gtest.AssertIN(s.String(), []string{"a,b", "b,a"})
+    })
 }
\ No newline at end of file
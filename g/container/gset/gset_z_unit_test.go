@@ -9,10 +9,20 @@
 package gset_test
 
 import (
+    "bytes"
+    "encoding/gob"
+    "encoding/json"
+    "errors"
     "github.com/gogf/gf/g/container/garray"
     "github.com/gogf/gf/g/container/gset"
     "github.com/gogf/gf/g/test/gtest"
+    "github.com/gogf/gf/third/gopkg.in/yaml.v2"
+    "io"
+    "strings"
+    "sync"
+    "sync/atomic"
     "testing"
+    "time"
 )
 
 func TestSet_Basic(t *testing.T) {
@@ -35,6 +45,522 @@ func TestSet_Basic(t *testing.T) {
     })
 }
 
+func TestSet_AddSlice(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(1)
+        gtest.Assert(s.AddSlice([]interface{}{1, 2, 3}).Size(), 3)
+        gtest.Assert(s.Contains(3), true)
+        gtest.Assert(s.AddSlice(nil).Size(), 3)
+    })
+}
+
+func TestSet_AddString(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.AddString("a; b;;c ; ", ";")
+        gtest.Assert(s.Equal(gset.NewFrom([]string{"a", "b", "c"})), true)
+        gtest.Assert(gset.NewSet().AddString(s.Join("|"), "|").Equal(s), true)
+        gtest.Assert(gset.NewSet().AddString("", ",").Size(), 0)
+    })
+}
+
+func TestSet_AddReturn(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(2)
+        gtest.Assert(s.AddReturn(3, 2, 1, 3), []interface{}{3, 1})
+        gtest.Assert(s.Size(), 3)
+        gtest.Assert(len(s.AddReturn(1, 2)), 0)
+    })
+}
+
+func TestSet_NewFrom(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewFrom([]int{1, 2, 2, 3})
+        gtest.Assert(s1.Size(), 3)
+        gtest.Assert(s1.Contains(1), true)
+        gtest.Assert(s1.Contains(3), true)
+
+        s2 := gset.NewFrom([]string{"a", "b"}, true)
+        gtest.Assert(s2.Size(), 2)
+        gtest.Assert(s2.Contains("a"), true)
+
+        gtest.Assert(gset.NewFrom(nil).Size(), 0)
+        gtest.Assert(gset.NewFrom(1).Size(), 0)
+    })
+}
+
+func TestOf(t *testing.T) {
+    gtest.Case(t, func() {
+        gtest.Assert(gset.Of().Size(), 0)
+        gtest.Assert(gset.Of(1, 2, 2, "a").Equal(gset.NewFrom([]interface{}{1, 2, "a"})), true)
+    })
+}
+
+func TestSet_NewFromAny(t *testing.T) {
+    gtest.Case(t, func() {
+        gtest.Assert(gset.NewFromAny(nil).Size(), 0)
+        gtest.Assert(gset.NewFromAny([]int{1, 2, 2}).Equal(gset.NewFrom([]int{1, 2})), true)
+        gtest.Assert(gset.NewFromAny("a,b,,c").Equal(gset.NewFrom([]string{"a", "b", "c"})), true)
+        gtest.Assert(gset.NewFromAny(`["a","b"]`).Equal(gset.NewFrom([]string{"a", "b"})), true)
+        gtest.Assert(gset.NewFromAny([]byte(`["a","b"]`), true).Size(), 2)
+        gtest.Assert(gset.NewFromAny(`[1,`).Size(), 0)
+        gtest.Assert(gset.NewFromAny(1).Slice(), []interface{}{1})
+    })
+}
+
+func TestSet_NewFromLines(t *testing.T) {
+    gtest.Case(t, func() {
+        s, err := gset.NewFromLines(strings.NewReader("a\n  b \n\n\t\nc\r\na\n"))
+        gtest.Assert(err, nil)
+        gtest.Assert(s.Equal(gset.NewFrom([]string{"a", "b", "c"})), true)
+
+        s, err = gset.NewFromLines(strings.NewReader(""), true)
+        gtest.Assert(err, nil)
+        gtest.Assert(s.Size(), 0)
+
+        s, err = gset.NewFromLines(strings.NewReader(strings.Repeat("a", 1 << 17)))
+        gtest.AssertNE(err, nil)
+        gtest.Assert(s == nil, true)
+    })
+}
+
+func TestSet_NewSize(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSize(100)
+        gtest.Assert(s.Size(), 0)
+        for i := 0; i < 200; i++ {
+            s.Add(i)
+        }
+        gtest.Assert(s.Size(), 200)
+    })
+}
+
+func TestSet_Remove(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(1, 2, 3, 4)
+        s.Remove(1, 3, 5)
+        gtest.Assert(s.Size(), 2)
+        gtest.Assert(s.Contains(2), true)
+        gtest.Assert(s.Contains(4), true)
+        s.Remove()
+        gtest.Assert(s.Size(), 2)
+    })
+}
+
+func TestSet_RemoveIf(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(1, 2, 3, 4, 5)
+        s.RemoveIf(func(v interface{}) bool {
+            return v.(int) > 2
+        })
+        gtest.Assert(s.Equal(gset.NewFrom([]int{1, 2})), true)
+    })
+}
+
+func TestSet_OnChange(t *testing.T) {
+    gtest.Case(t, func() {
+        s       := gset.NewSet()
+        added   := garray.New()
+        removed := garray.New()
+        count   := 0
+        s.OnChange(func(a, r interface{}) {
+            if a != nil {
+                added.Append(a)
+            }
+            if r != nil {
+                removed.Append(r)
+            }
+        }).OnChange(func(a, r interface{}) {
+            count = s.Size()
+        })
+        s.Add(1, 2)
+        s.Add(2, 3)
+        s.Remove(1, 4)
+        gtest.Assert(added.Slice(), []interface{}{1, 2, 3})
+        gtest.Assert(removed.Slice(), []interface{}{1})
+        gtest.Assert(count, 2)
+    })
+}
+
+func TestSet_OnChange_AllMutations(t *testing.T) {
+    gtest.Case(t, func() {
+        s     := gset.NewSet()
+        count := 0
+        s.OnChange(func(a, r interface{}) {
+            if a != nil {
+                count++
+            }
+            if r != nil {
+                count--
+            }
+        })
+        s.Add(1, 2)
+        s.AddSlice([]interface{}{3, 4})
+        gtest.Assert(count, 4)
+        s.Pop()
+        gtest.Assert(count, s.Size())
+        s.Clear()
+        gtest.Assert(count, 0)
+        gtest.Assert(s.Size(), 0)
+
+        s.AddString("a, b", ",")
+        s.AddReturn("b", "c")
+        s.AddIfNotExist("d")
+        s.AddIfNotExistFunc("e", func() bool { return true })
+        gtest.Assert(count, 5)
+        s.Pops(2)
+        gtest.Assert(count, s.Size())
+        s.Flush()
+        gtest.Assert(count, 0)
+
+        s.Replace(1, 2, 3, 4)
+        gtest.Assert(count, 4)
+        s.RemoveIf(func(v interface{}) bool { return v.(int) > 3 })
+        s.Retain(gset.NewFrom([]int{1, 2}))
+        gtest.Assert(count, 2)
+        s.Walk(func(v interface{}) interface{} { return v.(int) * 10 })
+        gtest.Assert(s.SortedInts(), []int{10, 20})
+        gtest.Assert(count, 2)
+        s.Merge(gset.NewFrom([]int{20, 30}))
+        s.AddAll(gset.NewFrom([]int{40}))
+        gtest.Assert(count, 4)
+        gtest.Assert(count, s.Size())
+
+        dst      := gset.NewSet()
+        dstCount := 0
+        dst.OnChange(func(a, r interface{}) {
+            if a != nil {
+                dstCount++
+            }
+        })
+        s.MoveTo(dst, 10)
+        gtest.Assert(count, 3)
+        gtest.Assert(dstCount, 1)
+        s.MoveTo(dst)
+        gtest.Assert(count, 0)
+        gtest.Assert(dstCount, 4)
+
+        gtest.Assert(json.Unmarshal([]byte("[1,2,3]"), s), nil)
+        gtest.Assert(count, 3)
+        gtest.Assert(s.Scan("a,b"), nil)
+        gtest.Assert(count, 2)
+        gtest.Assert(count, s.Size())
+    })
+}
+
+func TestSet_OnSizeExceed(t *testing.T) {
+    gtest.Case(t, func() {
+        s     := gset.NewSet()
+        sizes := garray.New()
+        s.OnSizeExceed(2, func(size int) {
+            sizes.Append(size)
+        })
+        s.Add(1, 2)
+        gtest.Assert(sizes.Len(), 0)
+        s.Add(3, 4)
+        gtest.Assert(sizes.Slice(), []interface{}{4})
+        s.Remove(1, 2, 3)
+        s.Add(5, 6)
+        gtest.Assert(sizes.Slice(), []interface{}{4})
+    })
+    gtest.Case(t, func() {
+        s     := gset.NewSet()
+        sizes := garray.New()
+        s.OnSizeExceed(2, func(size int) {
+            sizes.Append(s.Size())
+        }, true)
+        s.Add(1, 2, 3)
+        s.Add(4)
+        gtest.Assert(sizes.Slice(), []interface{}{3})
+        s.Remove(1, 2, 3)
+        s.Add(5, 6)
+        gtest.Assert(sizes.Slice(), []interface{}{3, 3})
+    })
+}
+
+func TestSet_OnSizeExceed_AllMutations(t *testing.T) {
+    gtest.Case(t, func() {
+        s     := gset.NewSet()
+        sizes := garray.New()
+        s.OnSizeExceed(2, func(size int) {
+            sizes.Append(size)
+        }, true)
+        s.AddSlice([]interface{}{1, 2, 3})
+        gtest.Assert(sizes.Slice(), []interface{}{3})
+        s.Pops(2)
+        s.Merge(gset.NewFrom([]int{4, 5, 6}))
+        gtest.Assert(sizes.Slice(), []interface{}{3, 4})
+        s.Clear()
+        gtest.Assert(json.Unmarshal([]byte("[1,2,3,4,5]"), s), nil)
+        gtest.Assert(sizes.Slice(), []interface{}{3, 4, 5})
+        s.Flush()
+        gset.NewFrom([]int{7, 8, 9}).MoveTo(s)
+        gtest.Assert(sizes.Slice(), []interface{}{3, 4, 5, 3})
+        // 集合大小未回落到阈值以内, 不会再次触发.
+        s.Replace(1, 2, 3, 4)
+        gtest.Assert(sizes.Slice(), []interface{}{3, 4, 5, 3})
+    })
+}
+
+func TestSet_SetValidator(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.SetValidator(func(v interface{}) error {
+            if n, ok := v.(int); !ok || n < 0 {
+                return errors.New("invalid item")
+            }
+            return nil
+        })
+        s.Add(1, -1, "a")
+        s.AddSlice([]interface{}{2, -2})
+        s.AddString("b,c", ",")
+        gtest.Assert(s.AddReturn(3, -3), []interface{}{3})
+        gtest.Assert(s.AddIfNotExist(-4), false)
+        gtest.Assert(s.AddIfNotExistFunc(-5, func() bool { return true }), false)
+        gtest.Assert(s.AddIfNotExist(4), true)
+        gtest.Assert(s.SortedInts(), []int{1, 2, 3, 4})
+
+        gtest.Assert(s.AddSafe(5, 6), nil)
+        gtest.Assert(s.AddSafe(7, -7, "d"), errors.New("invalid item"))
+        gtest.Assert(s.SortedInts(), []int{1, 2, 3, 4, 5, 6})
+
+        s.SetValidator(nil)
+        gtest.Assert(s.AddSafe(-1), nil)
+        gtest.Assert(s.Contains(-1), true)
+    })
+}
+
+func TestSet_SetValidator_AllInserts(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.SetValidator(func(v interface{}) error {
+            if v == "" {
+                return errors.New("empty item")
+            }
+            return nil
+        })
+        s.Replace("", "a")
+        gtest.Assert(s.Slice(), []interface{}{"a"})
+        s.Merge(gset.NewFrom([]string{"", "b"}))
+        s.AddAll(gset.NewFrom([]string{"", "c"}))
+        gtest.Assert(s.SortedStrings(), []string{"a", "b", "c"})
+        s.Walk(func(v interface{}) interface{} {
+            if v == "a" {
+                return ""
+            }
+            return v
+        })
+        gtest.Assert(s.SortedStrings(), []string{"b", "c"})
+        gtest.Assert(json.Unmarshal([]byte(`["", "d"]`), s), nil)
+        gtest.Assert(s.Slice(), []interface{}{"d"})
+
+        src := gset.NewFrom([]string{"", "e"})
+        src.MoveTo(s)
+        gtest.Assert(s.SortedStrings(), []string{"d", "e"})
+        gtest.Assert(src.Slice(), []interface{}{""})
+        src.Add("f")
+        src.MoveTo(s, "", "f")
+        gtest.Assert(s.SortedStrings(), []string{"d", "e", "f"})
+        gtest.Assert(src.Slice(), []interface{}{""})
+    })
+}
+
+func TestSet_Freeze(t *testing.T) {
+    // 捕获f执行时产生的panic.
+    panics := func(f func()) (panicked bool) {
+        defer func() {
+            panicked = recover() != nil
+        }()
+        f()
+        return
+    }
+    gtest.Case(t, func() {
+        s := gset.NewFrom([]int{1, 2, 3})
+        gtest.Assert(s.IsFrozen(), false)
+        s.Freeze()
+        gtest.Assert(s.IsFrozen(), true)
+        gtest.Assert(panics(func() { s.Add(4) }), true)
+        gtest.Assert(panics(func() { s.Remove(1) }), true)
+        gtest.Assert(panics(func() { s.Clear() }), true)
+        gtest.Assert(panics(func() { s.Merge(gset.NewFrom([]int{5})) }), true)
+        gtest.Assert(panics(func() { json.Unmarshal([]byte(`[5]`), s) }), true)
+        gtest.Assert(panics(func() { s.Contains(1) }), false)
+        gtest.Assert(s.SortedInts(), []int{1, 2, 3})
+        gtest.Assert(s.Union(gset.NewFrom([]int{4})).Size(), 4)
+        gtest.Assert(s.Clone().Add(4).Size(), 4)
+    })
+    gtest.Case(t, func() {
+        s := gset.NewFrom([]int{1, 2, 3}).Freeze(true)
+        s.Add(4).Remove(1)
+        gtest.Assert(s.AddIfNotExist(5), false)
+        gtest.Assert(s.Pop(), nil)
+        gtest.Assert(len(s.Flush()), 0)
+        gtest.Assert(s.SortedInts(), []int{1, 2, 3})
+        other := gset.NewSet()
+        other.MoveTo(s, 1)
+        s.MoveTo(other)
+        gtest.Assert(s.Size(), 3)
+        gtest.Assert(other.Size(), 0)
+    })
+}
+
+func TestSet_ContainsAll(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(1, 2, 3)
+        gtest.Assert(s.ContainsAll(), true)
+        gtest.Assert(s.ContainsAll(1, 3), true)
+        gtest.Assert(s.ContainsAll(1, 2, 3), true)
+        gtest.Assert(s.ContainsAll(1, 4), false)
+    })
+}
+
+func TestSet_Get(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add("abc", 1)
+        v, ok := s.Get(strings.Repeat("abc", 1))
+        gtest.Assert(v, "abc")
+        gtest.Assert(ok, true)
+        v, ok = s.Get(1)
+        gtest.Assert(v, 1)
+        gtest.Assert(ok, true)
+        v, ok = s.Get(2)
+        gtest.Assert(v, nil)
+        gtest.Assert(ok, false)
+    })
+}
+
+func TestSet_ContainsAny(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(1, 2, 3)
+        gtest.Assert(s.ContainsAny(), false)
+        gtest.Assert(s.ContainsAny(4, 3), true)
+        gtest.Assert(s.ContainsAny(4, 5), false)
+    })
+}
+
+func TestSet_ContainsWhich(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewFrom([]int{1, 2, 3})
+        gtest.Assert(s.ContainsWhich(4, 3, 0, 1), []interface{}{3, 1})
+        gtest.Assert(s.ContainsWhich(4, 5), []interface{}{})
+        gtest.Assert(s.ContainsWhich(), []interface{}{})
+    })
+}
+
+func TestSet_ContainsI(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add("abc", 1)
+        gtest.Assert(s.ContainsI("abc"), true)
+        gtest.Assert(s.ContainsI("ABC"), true)
+        gtest.Assert(s.ContainsI("aBd"), false)
+        gtest.Assert(s.ContainsI("1"), true)
+    })
+}
+
+func TestSet_AddIfNotExist(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(1)
+        gtest.Assert(s.AddIfNotExist(1), false)
+        gtest.Assert(s.AddIfNotExist(2), true)
+        gtest.Assert(s.AddIfNotExist(2), false)
+        gtest.Assert(s.Size(), 2)
+    })
+}
+
+func TestSet_AddIfNotExistFunc(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(1)
+        gtest.Assert(s.AddIfNotExistFunc(1, func() bool { return true }), false)
+        gtest.Assert(s.AddIfNotExistFunc(2, func() bool { return false }), false)
+        gtest.Assert(s.Contains(2), false)
+        gtest.Assert(s.AddIfNotExistFunc(2, func() bool { return true }), true)
+        gtest.Assert(s.Contains(2), true)
+    })
+}
+
+func TestSet_Pop(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(1, 2, 3)
+        a := garray.New()
+        for i := 0; i < 3; i++ {
+            v := s.Pop()
+            gtest.AssertIN(v, []interface{}{1, 2, 3})
+            gtest.Assert(a.Contains(v), false)
+            a.Append(v)
+        }
+        gtest.Assert(s.Size(), 0)
+        gtest.Assert(s.Pop(), nil)
+    })
+}
+
+func TestSet_Pops(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(1, 2, 3, 4)
+        a := s.Pops(2)
+        gtest.Assert(len(a), 2)
+        gtest.Assert(s.Size(), 2)
+        for _, v := range a {
+            gtest.Assert(s.Contains(v), false)
+        }
+        gtest.Assert(len(s.Pops(0)), 0)
+        gtest.Assert(len(s.Pops(10)), 2)
+        gtest.Assert(s.Size(), 0)
+    })
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(1, 2, 3)
+        gtest.Assert(len(s.Pops(-1)), 3)
+        gtest.Assert(s.Size(), 0)
+    })
+}
+
+func TestSet_Rand(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        gtest.Assert(s.Rand(), nil)
+        gtest.Assert(len(s.Rands(2)), 0)
+        s.Add(1, 2, 3)
+        for i := 0; i < 10; i++ {
+            gtest.AssertIN(s.Rand(), []interface{}{1, 2, 3})
+        }
+        gtest.Assert(s.Size(), 3)
+
+        a := s.Rands(2)
+        gtest.Assert(len(a), 2)
+        gtest.AssertNE(a[0], a[1])
+        gtest.Assert(s.ContainsAll(a...), true)
+        gtest.Assert(len(s.Rands(10)), 3)
+        gtest.Assert(len(s.Rands(-1)), 0)
+        gtest.Assert(s.Size(), 3)
+    })
+}
+
+func TestSet_Any(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        v, ok := s.Any()
+        gtest.Assert(v, nil)
+        gtest.Assert(ok, false)
+        s.Add(1, 2)
+        v, ok = s.Any()
+        gtest.AssertIN(v, []interface{}{1, 2})
+        gtest.Assert(ok, true)
+        gtest.Assert(s.Size(), 2)
+    })
+}
+
 func TestSet_Iterator(t *testing.T) {
     gtest.Case(t, func() {
         s := gset.NewSet()
@@ -56,6 +582,205 @@ func TestSet_Iterator(t *testing.T) {
     })
 }
 
+func TestSet_IteratorIndexed(t *testing.T) {
+    gtest.Case(t, func() {
+        s       := gset.NewFrom([]int{1, 2, 3, 4})
+        indexes := make([]int, 0)
+        items   := gset.NewSet()
+        s.IteratorIndexed(func(index int, v interface{}) bool {
+            indexes = append(indexes, index)
+            items.Add(v)
+            return true
+        })
+        gtest.Assert(indexes, []int{0, 1, 2, 3})
+        gtest.Assert(items.Equal(s), true)
+
+        indexes = indexes[:0]
+        s.IteratorIndexed(func(index int, v interface{}) bool {
+            indexes = append(indexes, index)
+            return index < 1
+        })
+        gtest.Assert(indexes, []int{0, 1})
+    })
+}
+
+func TestSet_IteratorMutable(t *testing.T) {
+    gtest.Case(t, func() {
+        s       := gset.NewFrom([]int{1, 2, 3, 4, 5})
+        removed := garray.New()
+        s.OnChange(func(a, r interface{}) {
+            if r != nil {
+                removed.Append(r)
+            }
+        })
+        s.IteratorMutable(func(v interface{}) bool {
+            return v.(int) % 2 == 1
+        })
+        gtest.Assert(s.SortedInts(), []int{1, 3, 5})
+        gtest.Assert(removed.Len(), 2)
+        gtest.AssertIN(2, removed.Slice())
+        gtest.AssertIN(4, removed.Slice())
+
+        s.IteratorMutable(func(v interface{}) bool {
+            return true
+        })
+        gtest.Assert(s.SortedInts(), []int{1, 3, 5})
+    })
+}
+
+func TestSet_IteratorSnapshot(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(1, 2, 3)
+        n := 0
+        s.IteratorSnapshot(func(v interface{}) bool {
+            s.Remove(v)
+            s.Add(v.(int) * 10)
+            n++
+            return true
+        })
+        gtest.Assert(n, 3)
+        gtest.Assert(s.Size(), 3)
+        gtest.Assert(s.ContainsAll(10, 20, 30), true)
+    })
+}
+
+func TestSet_ForEachParallel(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        for i := 1; i <= 100; i++ {
+            s.Add(i)
+        }
+        sum := int64(0)
+        s.ForEachParallel(4, func(v interface{}) {
+            atomic.AddInt64(&sum, int64(v.(int)))
+        })
+        gtest.Assert(sum, 5050)
+
+        count := int64(0)
+        s.ForEachParallel(0, func(v interface{}) {
+            atomic.AddInt64(&count, 1)
+        })
+        gtest.Assert(count, 100)
+        gset.NewSet().ForEachParallel(4, func(v interface{}) {
+            atomic.AddInt64(&count, 1)
+        })
+        gtest.Assert(count, 100)
+    })
+}
+
+func TestSet_Filter(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        s1.Add(1, 2, 3, 4)
+        s2 := s1.Filter(func(v interface{}) bool {
+            return v.(int) % 2 == 0
+        })
+        gtest.Assert(s1.Size(), 4)
+        gtest.Assert(s2.Size(), 2)
+        gtest.Assert(s2.Contains(2), true)
+        gtest.Assert(s2.Contains(4), true)
+    })
+}
+
+func TestSet_Count(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(1, 2, 3, 4, 5)
+        gtest.Assert(s.Count(func(v interface{}) bool {
+            return v.(int) > 2
+        }), 3)
+        gtest.Assert(gset.NewSet().Count(func(v interface{}) bool {
+            return true
+        }), 0)
+    })
+}
+
+func TestSet_Map(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        s1.Add(1, 2, 3, 4)
+        s2 := s1.Map(func(v interface{}) interface{} {
+            return v.(int) % 2
+        })
+        gtest.Assert(s1.Size(), 4)
+        gtest.Assert(s2.Size(), 2)
+        gtest.Assert(s2.Contains(0), true)
+        gtest.Assert(s2.Contains(1), true)
+    })
+}
+
+func TestSet_Partition(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(1, 2, 3, 4, 5)
+        matched, unmatched := s.Partition(func(v interface{}) bool {
+            return v.(int) % 2 == 0
+        })
+        gtest.Assert(matched.Equal(gset.NewFrom([]int{2, 4})), true)
+        gtest.Assert(unmatched.Equal(gset.NewFrom([]int{1, 3, 5})), true)
+        gtest.Assert(s.Size(), 5)
+    })
+}
+
+func TestSet_GroupBy(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(1, 2, 3, 4, 5, 6, 7)
+        groups := s.GroupBy(func(v interface{}) interface{} {
+            return v.(int) % 3
+        })
+        gtest.Assert(len(groups), 3)
+        gtest.Assert(groups[0].Equal(gset.NewFrom([]int{3, 6})), true)
+        gtest.Assert(groups[1].Equal(gset.NewFrom([]int{1, 4, 7})), true)
+        gtest.Assert(groups[2].Equal(gset.NewFrom([]int{2, 5})), true)
+        gtest.Assert(len(gset.NewSet().GroupBy(func(v interface{}) interface{} {
+            return v
+        })), 0)
+    })
+}
+
+func TestSet_CountBy(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(1, 2, 3, 4, 5, 6, 7)
+        counts := s.CountBy(func(v interface{}) interface{} {
+            return v.(int) % 3
+        })
+        gtest.Assert(counts, map[interface{}]int{0 : 2, 1 : 3, 2 : 2})
+        gtest.Assert(len(gset.NewSet().CountBy(func(v interface{}) interface{} {
+            return v
+        })), 0)
+    })
+}
+
+func TestSet_Shard(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        for i := 0; i < 100; i++ {
+            s.Add(i)
+        }
+        shards := s.Shard(4)
+        gtest.Assert(len(shards), 4)
+        union := gset.NewSet()
+        total := 0
+        for _, shard := range shards {
+            total += shard.Size()
+            union.Merge(shard)
+        }
+        gtest.Assert(total, 100)
+        gtest.Assert(union.Equal(s), true)
+        for i, shard := range s.Shard(4) {
+            gtest.Assert(shard.Equal(shards[i]), true)
+        }
+
+        shards = gset.NewFrom([]int{1}).Shard(3)
+        gtest.Assert(len(shards), 3)
+        gtest.Assert(shards[0].Size() + shards[1].Size() + shards[2].Size(), 1)
+        gtest.Assert(s.Shard(0) == nil, true)
+    })
+}
+
 func TestSet_LockFunc(t *testing.T) {
     gtest.Case(t, func() {
         s := gset.NewSet()
@@ -74,6 +799,21 @@ func TestSet_LockFunc(t *testing.T) {
     })
 }
 
+func TestSet_LockFuncResult(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(1, 2)
+        size := s.LockFuncResult(func(m map[interface{}]struct{}) interface{} {
+            if _, ok := m[3]; !ok {
+                m[3] = struct{}{}
+            }
+            return len(m)
+        })
+        gtest.Assert(size, 3)
+        gtest.Assert(s.Contains(3), true)
+    })
+}
+
 func TestSet_Equal(t *testing.T) {
     gtest.Case(t, func() {
         s1 := gset.NewSet()
@@ -87,6 +827,35 @@ func TestSet_Equal(t *testing.T) {
     })
 }
 
+func TestSet_EqualFunc(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        s2 := gset.NewSet()
+        s1.Add("a", "B", "c")
+        s2.Add("A", "b", "C", "c")
+        lower := func(v interface{}) interface{} {
+            return strings.ToLower(v.(string))
+        }
+        gtest.Assert(s1.Equal(s2), false)
+        gtest.Assert(s1.EqualFunc(s2, lower), true)
+        s2.Add("d")
+        gtest.Assert(s1.EqualFunc(s2, lower), false)
+    })
+}
+
+func TestSet_EqualSlice(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        gtest.Assert(s.EqualSlice(nil), true)
+        s.Add(1, 2, 3)
+        gtest.Assert(s.EqualSlice([]interface{}{3, 2, 1}), true)
+        gtest.Assert(s.EqualSlice([]interface{}{1, 1, 2, 3, 3}), true)
+        gtest.Assert(s.EqualSlice([]interface{}{1, 2}), false)
+        gtest.Assert(s.EqualSlice([]interface{}{1, 2, 4}), false)
+        gtest.Assert(s.EqualSlice([]interface{}{1, 2, 3, 4}), false)
+    })
+}
+
 func TestSet_IsSubsetOf(t *testing.T) {
     gtest.Case(t, func() {
         s1 := gset.NewSet()
@@ -103,6 +872,51 @@ func TestSet_IsSubsetOf(t *testing.T) {
     })
 }
 
+func TestSet_IsProperSubsetOf(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        s2 := gset.NewSet()
+        s3 := gset.NewSet()
+        s1.Add(1).Add(2)
+        s2.Add(1).Add(2).Add(3)
+        s3.Add(1).Add(2)
+        gtest.Assert(s1.IsProperSubsetOf(s2), true)
+        gtest.Assert(s2.IsProperSubsetOf(s1), false)
+        gtest.Assert(s1.IsProperSubsetOf(s3), false)
+        gtest.Assert(s1.IsSubsetOf(s3), true)
+        gtest.Assert(s1.IsProperSubsetOf(s1), false)
+    })
+}
+
+func TestSet_IsSupersetOf(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        s2 := gset.NewSet()
+        s1.Add(1).Add(2)
+        s2.Add(1).Add(2).Add(3)
+        gtest.Assert(s2.IsSupersetOf(s1), true)
+        gtest.Assert(s1.IsSupersetOf(s2), false)
+        gtest.Assert(s1.IsSupersetOf(s1), true)
+    })
+}
+
+func TestSet_IsDisjoint(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        s2 := gset.NewSet()
+        s3 := gset.NewSet()
+        s1.Add(1).Add(2)
+        s2.Add(3).Add(4).Add(5)
+        s3.Add(2).Add(3)
+        gtest.Assert(s1.IsDisjoint(s2), true)
+        gtest.Assert(s2.IsDisjoint(s1), true)
+        gtest.Assert(s1.IsDisjoint(s3), false)
+        gtest.Assert(s2.IsDisjoint(s3), false)
+        gtest.Assert(s1.IsDisjoint(s1), false)
+        gtest.Assert(gset.NewSet().IsDisjoint(s1), true)
+    })
+}
+
 func TestSet_Union(t *testing.T) {
     gtest.Case(t, func() {
         s1 := gset.NewSet()
@@ -117,6 +931,54 @@ func TestSet_Union(t *testing.T) {
     })
 }
 
+func TestSet_Union_Multiple(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        s2 := gset.NewSet()
+        s3 := gset.NewSet()
+        s4 := gset.NewSet()
+        s1.Add(1).Add(2)
+        s2.Add(2).Add(3)
+        s3.Add(4)
+        s4.Add(5)
+        s := s1.Union(s2, s1, s3, s4)
+        gtest.Assert(s.Size(), 5)
+        gtest.Assert(s.Contains(1), true)
+        gtest.Assert(s.Contains(3), true)
+        gtest.Assert(s.Contains(4), true)
+        gtest.Assert(s.Contains(5), true)
+        gtest.Assert(s1.Union().Equal(s1), true)
+        gtest.Assert(s1.Union(s1).Equal(s1), true)
+    })
+}
+
+func TestSet_UnionSize(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        s2 := gset.NewSet()
+        gtest.Assert(s1.UnionSize(s2), 0)
+        s1.Add(1, 2, 3)
+        s2.Add(3, 4)
+        gtest.Assert(s1.UnionSize(s2), 4)
+        gtest.Assert(s2.UnionSize(s1), 4)
+        gtest.Assert(s1.UnionSize(s1), 3)
+        gtest.Assert(s1.UnionSize(s2), s1.Union(s2).Size())
+    })
+}
+
+func TestUnionSizeOf(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewFrom([]int{1, 2, 3})
+        s2 := gset.NewFrom([]int{3, 4})
+        s3 := gset.NewFrom([]int{4, 5, 1})
+        gtest.Assert(gset.UnionSizeOf(), 0)
+        gtest.Assert(gset.UnionSizeOf(s1), 3)
+        gtest.Assert(gset.UnionSizeOf(s1, s2), 4)
+        gtest.Assert(gset.UnionSizeOf(s1, s2, s3, s1), 5)
+        gtest.Assert(gset.UnionSizeOf(s1, s2, s3), s1.Union(s2, s3).Size())
+    })
+}
+
 func TestSet_Diff(t *testing.T) {
     gtest.Case(t, func() {
         s1 := gset.NewSet()
@@ -131,6 +993,43 @@ func TestSet_Diff(t *testing.T) {
     })
 }
 
+func TestSet_DiffSlice(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        s1.Add(1, 2, 3)
+        s2 := s1.DiffSlice([]interface{}{3, 4, 5})
+        gtest.Assert(s2.Size(), 2)
+        gtest.Assert(s2.Contains(1), true)
+        gtest.Assert(s2.Contains(2), true)
+        gtest.Assert(s1.DiffSlice(nil).Equal(s1), true)
+    })
+}
+
+func TestSet_DiffSlices(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        s1.Add(1, 2, 3, 4, 5)
+        s2 := s1.DiffSlices([]interface{}{1, 2}, nil, []interface{}{}, []interface{}{5, 6})
+        gtest.Assert(s2.Equal(gset.NewFrom([]int{3, 4})), true)
+        gtest.Assert(s1.DiffSlices().Equal(s1), true)
+        gtest.Assert(s1.Size(), 5)
+    })
+}
+
+func TestSet_Diff_Multiple(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewFrom([]int{1, 2, 3, 4, 5})
+        s2 := gset.NewFrom([]int{1, 2})
+        s3 := gset.NewFrom([]int{5, 6})
+        gtest.Assert(s1.Diff(s2, s3).SortedInts(), []int{3, 4})
+        gtest.Assert(s1.Diff(s2, s3).Equal(s1.DiffSlices(s2.Slice(), s3.Slice())), true)
+        gtest.Assert(s1.Diff(s2, gset.NewSet()).SortedInts(), []int{3, 4, 5})
+        gtest.Assert(s1.Diff().Equal(s1), true)
+        gtest.Assert(s1.Diff(s2, s1).Size(), 0)
+        gtest.Assert(gset.NewSet().Diff(s1, s2).Size(), 0)
+    })
+}
+
 func TestSet_Intersect(t *testing.T) {
     gtest.Case(t, func() {
         s1 := gset.NewSet()
@@ -145,6 +1044,63 @@ func TestSet_Intersect(t *testing.T) {
     })
 }
 
+func TestSet_Intersect_Multiple(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewFrom([]int{1, 2, 3, 4, 5})
+        s2 := gset.NewFrom([]int{2, 3, 4})
+        s3 := gset.NewFrom([]int{3, 4, 6})
+        gtest.Assert(s1.Intersect(s2, s3).SortedInts(), []int{3, 4})
+        gtest.Assert(s3.Intersect(s1, s2).SortedInts(), []int{3, 4})
+        gtest.Assert(s1.Intersect(s2, s3, gset.NewFrom([]int{7})).Size(), 0)
+        gtest.Assert(s1.Intersect(s1, s2).SortedInts(), []int{2, 3, 4})
+        gtest.Assert(s1.Intersect().Size(), 0)
+        gtest.Assert(s1.Size(), 5)
+        gtest.Assert(s2.Size(), 3)
+        gtest.Assert(s3.Size(), 3)
+    })
+}
+
+func TestSet_IntersectFunc(t *testing.T) {
+    gtest.Case(t, func() {
+        s    := gset.NewFrom([]int{1, 2, 3, 4, 5})
+        even := func(v interface{}) bool {
+            return v.(int) % 2 == 0
+        }
+        gtest.Assert(s.IntersectFunc(even).SortedInts(), []int{2, 4})
+        gtest.Assert(s.Size(), 5)
+        gtest.Assert(gset.NewSet().IntersectFunc(even).Size(), 0)
+    })
+}
+
+func TestSet_Intersect_Empty(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        s2 := gset.NewSet()
+        s2.Add(1, 2)
+        gtest.Assert(s1.Intersect(s2).Size(), 0)
+        gtest.Assert(s2.Intersect(s1).Size(), 0)
+        gtest.Assert(s1.Diff(s2).Size(), 0)
+        gtest.Assert(s2.Diff(s1).Size(), 2)
+        gtest.Assert(s2.Diff(s1, s1).SortedInts(), []int{1, 2})
+        gtest.Assert(s2.Union(s1, s1).SortedInts(), []int{1, 2})
+        gtest.Assert(s1.Union(s1, s2).SortedInts(), []int{1, 2})
+    })
+}
+
+func TestSet_IntersectSize(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        s2 := gset.NewSet()
+        gtest.Assert(s1.IntersectSize(s2), 0)
+        s1.Add(1, 2, 3)
+        s2.Add(2, 3, 4, 5)
+        gtest.Assert(s1.IntersectSize(s2), 2)
+        gtest.Assert(s2.IntersectSize(s1), 2)
+        gtest.Assert(s1.IntersectSize(s1), 3)
+        gtest.Assert(s1.IntersectSize(gset.NewSet()), 0)
+    })
+}
+
 func TestSet_Complement(t *testing.T) {
     gtest.Case(t, func() {
         s1 := gset.NewSet()
@@ -157,4 +1113,779 @@ func TestSet_Complement(t *testing.T) {
         gtest.Assert(s3.Contains(4), true)
         gtest.Assert(s3.Contains(5), true)
     })
-}
\ No newline at end of file
+}
+
+func TestSet_Json(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        b, err := json.Marshal(s)
+        gtest.Assert(err, nil)
+        gtest.Assert(string(b), "[]")
+
+        s.Add(1, 2, 3)
+        b, err = json.Marshal(s)
+        gtest.Assert(err, nil)
+        a := make([]int, 0)
+        gtest.Assert(json.Unmarshal(b, &a), nil)
+        gtest.Assert(len(a), 3)
+        gtest.AssertIN(1, a)
+        gtest.AssertIN(2, a)
+        gtest.AssertIN(3, a)
+    })
+}
+
+func TestSet_MarshalJSONSorted(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        b, err := s.MarshalJSONSorted()
+        gtest.Assert(err, nil)
+        gtest.Assert(string(b), "[]")
+
+        s.Add("c", "a", "b", 3, 1)
+        for i := 0; i < 10; i++ {
+            b, err = s.MarshalJSONSorted()
+            gtest.Assert(err, nil)
+            gtest.Assert(string(b), `[1,3,"a","b","c"]`)
+        }
+    })
+}
+
+func TestSet_UnmarshalJSON(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add("z")
+        gtest.Assert(json.Unmarshal([]byte(`["a","b","c"]`), s), nil)
+        gtest.Assert(s.Size(), 3)
+        gtest.Assert(s.Contains("a"), true)
+        gtest.Assert(s.Contains("z"), false)
+
+        gtest.Assert(json.Unmarshal([]byte(`null`), s), nil)
+        gtest.Assert(s.Size(), 0)
+
+        gtest.AssertNE(json.Unmarshal([]byte(`{"a":1}`), s), nil)
+    })
+    gtest.Case(t, func() {
+        v := struct {
+            Name string
+            Set  *gset.Set
+        }{}
+        gtest.Assert(json.Unmarshal([]byte(`{"Name":"john","Set":["a","b"]}`), &v), nil)
+        gtest.Assert(v.Name, "john")
+        gtest.Assert(v.Set.Size(), 2)
+        gtest.Assert(v.Set.Contains("b"), true)
+    })
+}
+
+func TestSet_UnmarshalJSON_Numbers(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        gtest.Assert(json.Unmarshal([]byte(`[1,2,3,1.5,"1"]`), s), nil)
+        gtest.Assert(s.Size(), 5)
+        gtest.Assert(s.ContainsAll(1, 2, 3, 1.5, "1"), true)
+
+        s1 := gset.Of(1, 2, 3, "a")
+        b, err := json.Marshal(s1)
+        gtest.Assert(err, nil)
+        s2 := gset.NewSet()
+        gtest.Assert(json.Unmarshal(b, s2), nil)
+        gtest.Assert(s2.Equal(s1), true)
+
+        gtest.AssertNE(s2.UnmarshalJSON([]byte(`[1] [2]`)), nil)
+        gtest.Assert(s2.Equal(s1), true)
+    })
+}
+
+func TestSet_SymmetricDifference(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        s2 := gset.NewSet()
+        s1.Add(1).Add(2).Add(3)
+        s2.Add(3).Add(4).Add(5)
+        s3 := s1.SymmetricDifference(s2)
+        gtest.Assert(s3.Size(), 4)
+        gtest.Assert(s3.Contains(1), true)
+        gtest.Assert(s3.Contains(2), true)
+        gtest.Assert(s3.Contains(3), false)
+        gtest.Assert(s3.Contains(4), true)
+        gtest.Assert(s3.Contains(5), true)
+        gtest.Assert(s1.SymmetricDifference(s1).Size(), 0)
+    })
+}
+
+func TestSet_Delta(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewFrom([]int{1, 2, 3})
+        s2 := gset.NewFrom([]int{3, 4, 5})
+        added, removed := s1.Delta(s2)
+        gtest.Assert(added.Equal(gset.NewFrom([]int{4, 5})), true)
+        gtest.Assert(removed.Equal(gset.NewFrom([]int{1, 2})), true)
+
+        added, removed = s1.Delta(s1)
+        gtest.Assert(added.Size(), 0)
+        gtest.Assert(removed.Size(), 0)
+
+        added, removed = gset.NewSet().Delta(s1)
+        gtest.Assert(added.Equal(s1), true)
+        gtest.Assert(removed.Size(), 0)
+    })
+}
+
+func TestSet_DiffStats(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewFrom([]int{1, 2, 3, 4})
+        s2 := gset.NewFrom([]int{3, 4, 5})
+        onlyInSet, onlyInOther, common := s1.DiffStats(s2)
+        gtest.Assert(onlyInSet, 2)
+        gtest.Assert(onlyInOther, 1)
+        gtest.Assert(common, 2)
+
+        onlyInSet, onlyInOther, common = s1.DiffStats(s1)
+        gtest.Assert(onlyInSet, 0)
+        gtest.Assert(onlyInOther, 0)
+        gtest.Assert(common, 4)
+
+        onlyInSet, onlyInOther, common = gset.NewSet().DiffStats(s2)
+        gtest.Assert(onlyInSet, 0)
+        gtest.Assert(onlyInOther, 3)
+        gtest.Assert(common, 0)
+    })
+}
+
+func TestSet_Merge(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        s2 := gset.NewSet()
+        s3 := gset.NewSet()
+        s1.Add(1).Add(2)
+        s2.Add(2).Add(3)
+        s3.Add(4)
+        gtest.Assert(s1.Merge(s2, s3, s1) == s1, true)
+        gtest.Assert(s1.Size(), 4)
+        gtest.Assert(s1.Contains(1), true)
+        gtest.Assert(s1.Contains(3), true)
+        gtest.Assert(s1.Contains(4), true)
+        gtest.Assert(s2.Size(), 2)
+    })
+}
+
+func TestSet_AddAll(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewFrom([]int{1, 2})
+        s2 := gset.NewFrom([]int{2, 3})
+        gtest.Assert(s1.AddAll(s2) == s1, true)
+        gtest.Assert(s1.SortedInts(), []int{1, 2, 3})
+        gtest.Assert(s2.SortedInts(), []int{2, 3})
+        gtest.Assert(s1.AddAll(s1).SortedInts(), []int{1, 2, 3})
+        gtest.Assert(s1.AddAll(gset.NewSet()).Size(), 3)
+    })
+}
+
+func TestSet_Retain(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        s2 := gset.NewSet()
+        s1.Add(1, 2, 3)
+        s2.Add(2, 3, 4)
+        gtest.Assert(s1.Retain(s2) == s1, true)
+        gtest.Assert(s1.Equal(gset.NewFrom([]int{2, 3})), true)
+        gtest.Assert(s2.Size(), 3)
+        gtest.Assert(s1.Retain(s1).Size(), 2)
+        gtest.Assert(s1.Retain(gset.NewSet()).Size(), 0)
+    })
+}
+
+func TestSet_MoveTo(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewFrom([]int{1, 2, 3})
+        s2 := gset.NewFrom([]int{4})
+        gtest.Assert(s1.MoveTo(s2, 1, 3, 5) == s1, true)
+        gtest.Assert(s1.SortedInts(), []int{2})
+        gtest.Assert(s2.SortedInts(), []int{1, 3, 4})
+
+        s2.MoveTo(s1)
+        gtest.Assert(s1.SortedInts(), []int{1, 2, 3, 4})
+        gtest.Assert(s2.Size(), 0)
+
+        s1.MoveTo(s1, 1)
+        gtest.Assert(s1.Size(), 4)
+    })
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        s2 := gset.NewSet()
+        for i := 0; i < 1000; i++ {
+            s1.Add(i)
+        }
+        wg := sync.WaitGroup{}
+        for i := 0; i < 4; i++ {
+            wg.Add(1)
+            go func(i int) {
+                defer wg.Done()
+                for j := 0; j < 1000; j++ {
+                    if i % 2 == 0 {
+                        s1.MoveTo(s2, j)
+                    } else {
+                        s2.MoveTo(s1, j)
+                    }
+                }
+            }(i)
+        }
+        wg.Wait()
+        gtest.Assert(s1.Size() + s2.Size(), 1000)
+        gtest.Assert(s1.IsDisjoint(s2), true)
+    })
+}
+
+func TestSet_Clone(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewSet(true)
+        s1.Add(1).Add(2)
+        s2 := s1.Clone()
+        gtest.Assert(s2.Equal(s1), true)
+        s2.Add(3)
+        gtest.Assert(s1.Contains(3), false)
+        gtest.Assert(s2.Size(), 3)
+    })
+}
+
+func TestSet_CloneCOW(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewFrom([]int{1, 2, 3}, true)
+        s2 := s1.CloneCOW()
+        s3 := s1.CloneCOW()
+        gtest.Assert(s2.Equal(s1), true)
+        s1.Add(4)
+        s2.Remove(1)
+        s3.LockFunc(func(m map[interface{}]struct{}) {
+            m[5] = struct{}{}
+        })
+        gtest.Assert(s1.SortedInts(), []int{1, 2, 3, 4})
+        gtest.Assert(s2.SortedInts(), []int{2, 3})
+        gtest.Assert(s3.SortedInts(), []int{1, 2, 3, 5})
+
+        s4 := s2.CloneCOW()
+        s4.MoveTo(s2, 2)
+        s2.Merge(gset.NewFrom([]int{6}))
+        gtest.Assert(s2.SortedInts(), []int{2, 3, 6})
+        gtest.Assert(s4.SortedInts(), []int{3})
+    })
+    gtest.Case(t, func() {
+        // 替换底层map后集合独占新的map, 之后的修改不会影响共享原map的集合.
+        s1 := gset.NewFrom([]int{1, 2, 3})
+        s2 := s1.CloneCOW()
+        s1.Replace(4, 5)
+        s1.Add(6)
+        s2.Clear()
+        s2.Add(7)
+        gtest.Assert(s1.SortedInts(), []int{4, 5, 6})
+        gtest.Assert(s2.SortedInts(), []int{7})
+    })
+    gtest.Case(t, func() {
+        s  := gset.NewFrom([]int{1, 2, 3})
+        wg := sync.WaitGroup{}
+        for i := 0; i < 10; i++ {
+            snapshot := s.CloneCOW()
+            wg.Add(1)
+            go func() {
+                defer wg.Done()
+                for j := 0; j < 100; j++ {
+                    snapshot.Contains(j)
+                    snapshot.Size()
+                }
+            }()
+            s.Add(i + 10)
+        }
+        wg.Wait()
+        gtest.Assert(s.Size(), 13)
+    })
+}
+
+func TestSet_Flush(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(1, 2, 3)
+        a := s.Flush()
+        gtest.Assert(len(a), 3)
+        gtest.AssertIN(2, a)
+        gtest.Assert(s.Size(), 0)
+        gtest.Assert(len(s.Flush()), 0)
+    })
+    gtest.Case(t, func() {
+        s     := gset.NewSet()
+        total := 0
+        done  := make(chan struct{})
+        go func() {
+            for i := 0; i < 1000; i++ {
+                s.Add(i)
+            }
+            close(done)
+        }()
+        for {
+            select {
+                case <-done:
+                    total += len(s.Flush())
+                    gtest.Assert(total, 1000)
+                    return
+                default:
+                    total += len(s.Flush())
+            }
+        }
+    })
+}
+
+func TestSet_Replace(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(1, 2, 3)
+        gtest.Assert(s.Replace(3, 4, 4) == s, true)
+        gtest.Assert(s.Equal(gset.NewFrom([]int{3, 4})), true)
+        gtest.Assert(s.Replace().Size(), 0)
+    })
+    gtest.Case(t, func() {
+        s  := gset.NewSet()
+        a  := []interface{}{1, 2, 3}
+        b  := []interface{}{4, 5, 6, 7}
+        wg := sync.WaitGroup{}
+        s.Replace(a...)
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for i := 0; i < 1000; i++ {
+                if i % 2 == 0 {
+                    s.Replace(b...)
+                } else {
+                    s.Replace(a...)
+                }
+            }
+        }()
+        for i := 0; i < 1000; i++ {
+            size := s.Size()
+            gtest.AssertIN(size, []int{3, 4})
+        }
+        wg.Wait()
+    })
+}
+
+func TestSet_Compact(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        for i := 0; i < 1000; i++ {
+            s.Add(i)
+        }
+        for i := 0; i < 990; i++ {
+            s.Remove(i)
+        }
+        gtest.Assert(s.Compact() == s, true)
+        gtest.Assert(s.Size(), 10)
+        gtest.Assert(s.SortedInts(), []int{990, 991, 992, 993, 994, 995, 996, 997, 998, 999})
+        gtest.Assert(gset.NewSet().Compact().Size(), 0)
+    })
+}
+
+func TestSet_DeepClone(t *testing.T) {
+    type User struct {
+        Name string
+        Tags []string
+    }
+    gtest.Case(t, func() {
+        u  := &User{Name : "john", Tags : []string{"a"}}
+        s1 := gset.NewSet()
+        s1.Add(1, "a", u)
+        s2, err := s1.DeepClone()
+        gtest.Assert(err, nil)
+        gtest.Assert(s2.Size(), 3)
+        gtest.Assert(s2.ContainsAll(1, "a"), true)
+        gtest.Assert(s2.Contains(u), false)
+        s2.Iterator(func(v interface{}) bool {
+            if c, ok := v.(*User); ok {
+                gtest.Assert(c.Name, "john")
+                c.Tags[0] = "b"
+            }
+            return true
+        })
+        gtest.Assert(u.Tags[0], "a")
+    })
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        s1.Add(1, 2)
+        s2, err := s1.DeepClone(func(v interface{}) interface{} {
+            return v.(int) * 10
+        })
+        gtest.Assert(err, nil)
+        gtest.Assert(s2.Equal(gset.NewFrom([]int{10, 20})), true)
+    })
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(make(chan int))
+        _, err := s.DeepClone()
+        gtest.AssertNE(err, nil)
+    })
+}
+
+func TestSet_Sum(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        gtest.Assert(s.Sum(), 0)
+        s.Add(1, 2, 3)
+        gtest.Assert(s.Sum(), 6)
+    })
+}
+
+func TestSet_Reduce(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        product := func(acc, v interface{}) interface{} {
+            return acc.(int) * v.(int)
+        }
+        gtest.Assert(s.Reduce(1, product), 1)
+        s.Add(2, 3, 4)
+        gtest.Assert(s.Reduce(1, product), 24)
+    })
+}
+
+func TestSet_SortedSlice(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(3, 1, 2)
+        gtest.Assert(s.SortedSlice(nil), []interface{}{1, 2, 3})
+        gtest.Assert(s.SortedSlice(func(v1, v2 interface{}) bool {
+            return v1.(int) > v2.(int)
+        }), []interface{}{3, 2, 1})
+    })
+}
+
+func TestSet_IntsStrings(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(1, "2", 3.0)
+        ints := s.Ints()
+        gtest.Assert(len(ints), 3)
+        gtest.AssertIN(1, ints)
+        gtest.AssertIN(2, ints)
+        gtest.AssertIN(3, ints)
+        strs := s.Strings()
+        gtest.Assert(len(strs), 3)
+        gtest.AssertIN("1", strs)
+        gtest.AssertIN("2", strs)
+        gtest.AssertIN("3", strs)
+        gtest.Assert(len(gset.NewSet().Ints()), 0)
+    })
+}
+
+func TestSet_BoolMap(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewFrom([]interface{}{1, "a"})
+        m := s.BoolMap()
+        gtest.Assert(len(m), 2)
+        gtest.Assert(m[1], true)
+        gtest.Assert(m["a"], true)
+        gtest.Assert(m[2], false)
+        m[2] = true
+        gtest.Assert(s.Contains(2), false)
+        gtest.Assert(len(gset.NewSet().BoolMap()), 0)
+    })
+}
+
+func TestSet_SortedIntsStrings(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(10, "2", 3.0)
+        gtest.Assert(s.SortedInts(), []int{2, 3, 10})
+        gtest.Assert(s.SortedStrings(), []string{"10", "2", "3"})
+    })
+}
+
+func TestSet_Walk(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(1, 2, 3)
+        s.Walk(func(item interface{}) interface{} {
+            return item.(int)+10
+        })
+        gtest.Assert(s.Size(), 3)
+        gtest.Assert(s.Contains(1), false)
+        gtest.Assert(s.Contains(11), true)
+        gtest.Assert(s.Contains(12), true)
+        gtest.Assert(s.Contains(13), true)
+    })
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(1, 2, 3)
+        s.Walk(func(item interface{}) interface{} {
+            return 0
+        })
+        gtest.Assert(s.Size(), 1)
+        gtest.Assert(s.Contains(0), true)
+    })
+}
+
+func TestSet_Similarity(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        s2 := gset.NewSet()
+        gtest.Assert(s1.Similarity(s2), 0)
+        s1.Add(1, 2, 3)
+        s2.Add(2, 3, 4)
+        gtest.Assert(s1.Similarity(s2), 0.5)
+        gtest.Assert(s2.Similarity(s1), 0.5)
+        gtest.Assert(s1.Similarity(s1), 1)
+        gtest.Assert(s1.Similarity(gset.NewSet()), 0)
+    })
+}
+
+func TestSet_ValueScan(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        s1.Add("a", "b", "c")
+        v, err := s1.Value()
+        gtest.Assert(err, nil)
+
+        s2 := gset.NewSet()
+        s2.Add("z")
+        gtest.Assert(s2.Scan(v), nil)
+        gtest.Assert(s2.Equal(s1), true)
+
+        gtest.Assert(s2.Scan([]byte(`["x","y"]`)), nil)
+        gtest.Assert(s2.Size(), 2)
+        gtest.Assert(s2.Contains("x"), true)
+
+        gtest.Assert(s2.Scan(nil), nil)
+        gtest.Assert(s2.Size(), 0)
+    })
+    gtest.Case(t, func() {
+        s := new(gset.Set)
+        gtest.Assert(s.Scan("1,2,,3"), nil)
+        gtest.Assert(s.Size(), 3)
+        gtest.Assert(s.Contains("2"), true)
+    })
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        s1.Add("a,b", 1, 2.5, true)
+        v, err := s1.Value()
+        gtest.Assert(err, nil)
+        s2 := gset.NewSet()
+        gtest.Assert(s2.Scan(v), nil)
+        gtest.Assert(s2.Size(), 4)
+        gtest.Assert(s2.Equal(s1), true)
+        gtest.Assert(s2.Contains("a,b"), true)
+        gtest.Assert(s2.Contains(1), true)
+    })
+}
+
+func TestSet_Gob(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        s1.Add(1, 2, "a", "b")
+        buffer := bytes.NewBuffer(nil)
+        gtest.Assert(gob.NewEncoder(buffer).Encode(s1), nil)
+
+        s2 := gset.NewSet()
+        s2.Add("z")
+        gtest.Assert(gob.NewDecoder(buffer).Decode(s2), nil)
+        gtest.Assert(s2.Size(), 4)
+        gtest.Assert(s2.Equal(s1), true)
+    })
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        b, err := s1.GobEncode()
+        gtest.Assert(err, nil)
+        s2 := new(gset.Set)
+        gtest.Assert(s2.GobDecode(b), nil)
+        gtest.Assert(s2.Size(), 0)
+    })
+}
+
+func TestSet_Hash(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        s2 := gset.NewSet()
+        gtest.Assert(s1.Hash(), s2.Hash())
+        s1.Add(1, 2, 3)
+        s2.Add(3, 2, 1)
+        gtest.Assert(s1.Hash(), s2.Hash())
+        s2.Add(4)
+        gtest.AssertNE(s1.Hash(), s2.Hash())
+        s2.Remove(4)
+        gtest.Assert(s1.Hash(), s2.Hash())
+    })
+}
+
+func TestSet_Join(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add("a", "b", "c")
+        gtest.Assert(s.JoinSorted("|"), "a|b|c")
+        gtest.Assert(len(s.Join("|")), 5)
+        gtest.Assert(strings.Count(s.Join("|"), "|"), 2)
+        gtest.Assert(strings.Count(s.String(), ","), 2)
+        gtest.Assert(gset.NewSet().JoinSorted("|"), "")
+    })
+}
+
+func TestSet_JoinGlue(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add(1)
+        gtest.Assert(s.Join("-"), "1")
+        s.Add(2)
+        for _, glue := range []string{"-", "|", ", ", ""} {
+            gtest.AssertIN(s.Join(glue), []string{"1" + glue + "2", "2" + glue + "1"})
+        }
+    })
+}
+
+func TestSet_WriteLines(t *testing.T) {
+    gtest.Case(t, func() {
+        s      := gset.NewFrom([]interface{}{"a", 1, "bc"})
+        buffer := bytes.NewBuffer(nil)
+        n, err := s.WriteLines(buffer)
+        gtest.Assert(err, nil)
+        gtest.Assert(n, 7)
+        gtest.Assert(buffer.Len(), 7)
+        lines, err := gset.NewFromLines(buffer)
+        gtest.Assert(err, nil)
+        gtest.Assert(lines.Equal(gset.NewFrom([]string{"a", "1", "bc"})), true)
+
+        n, err = gset.NewSet().WriteLines(buffer)
+        gtest.Assert(err, nil)
+        gtest.Assert(n, 0)
+    })
+    gtest.Case(t, func() {
+        r, w := io.Pipe()
+        r.Close()
+        n, err := gset.NewFrom([]int{1, 2}).WriteLines(w)
+        gtest.Assert(err, io.ErrClosedPipe)
+        gtest.Assert(n, 0)
+    })
+}
+
+func TestSet_MinMax(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        gtest.Assert(s.Min(nil), nil)
+        gtest.Assert(s.Max(nil), nil)
+        s.Add(3, 10, 2.5, "7")
+        gtest.Assert(s.Min(nil), 2.5)
+        gtest.Assert(s.Max(nil), 10)
+    })
+    gtest.Case(t, func() {
+        s := gset.NewSet()
+        s.Add("b", "abc", "cd")
+        less := func(v1, v2 interface{}) bool {
+            return len(v1.(string)) < len(v2.(string))
+        }
+        gtest.Assert(s.Min(less), "b")
+        gtest.Assert(s.Max(less), "abc")
+    })
+}
+
+func TestSet_Yaml(t *testing.T) {
+    gtest.Case(t, func() {
+        v := struct {
+            Allowed *gset.Set `yaml:"allowed"`
+        }{gset.NewSet()}
+        b, err := yaml.Marshal(v)
+        gtest.Assert(err, nil)
+        gtest.Assert(string(b), "allowed: []\n")
+
+        v.Allowed.Add("a")
+        b, err = yaml.Marshal(v)
+        gtest.Assert(err, nil)
+        gtest.Assert(string(b), "allowed:\n- a\n")
+    })
+    gtest.Case(t, func() {
+        v := struct {
+            Allowed *gset.Set `yaml:"allowed"`
+        }{}
+        gtest.Assert(yaml.Unmarshal([]byte("allowed:\n- a\n- b\n- 1\n"), &v), nil)
+        gtest.Assert(v.Allowed.Size(), 3)
+        gtest.Assert(v.Allowed.ContainsAll("a", "b", 1), true)
+        gtest.AssertNE(yaml.Unmarshal([]byte("allowed: a"), &v), nil)
+    })
+}
+
+func TestSet_OperationSafety(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewSet()
+        s2 := gset.NewSet()
+        s1.Add(1, 2, 3)
+        s2.Add(2, 3, 4)
+        for _, s := range []*gset.Set{s1.Union(s2), s1.Diff(s2), s1.Intersect(s2), s1.Complement(s2)} {
+            wg := sync.WaitGroup{}
+            for i := 0; i < 10; i++ {
+                wg.Add(1)
+                go func(i int) {
+                    defer wg.Done()
+                    s.Add(i)
+                    s.Contains(i)
+                }(i)
+            }
+            wg.Wait()
+            gtest.Assert(s.ContainsAll(0, 1, 2, 3, 4, 5, 6, 7, 8, 9), true)
+        }
+    })
+}
+
+// assertNoDeadlock runs <op> concurrently in 4 goroutines for 1000 rounds each, half of which are
+// given <swapped> true, so that the operations on two sets are performed in both directions.
+// It fails the test if they do not finish in time, which means a deadlock.
+func assertNoDeadlock(t *testing.T, op func(swapped bool, round int)) {
+    done := make(chan struct{})
+    go func() {
+        defer close(done)
+        wg := sync.WaitGroup{}
+        for i := 0; i < 4; i++ {
+            wg.Add(1)
+            go func(swapped bool) {
+                defer wg.Done()
+                for j := 0; j < 1000; j++ {
+                    op(swapped, j)
+                }
+            }(i % 2 == 1)
+        }
+        wg.Wait()
+    }()
+    select {
+        case <-done:
+        case <-time.After(10*time.Second):
+            t.Error("deadlock in symmetric concurrent set operations")
+    }
+}
+
+func TestSet_LockOrder(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewFrom([]int{1, 2, 3})
+        s2 := gset.NewFrom([]int{2, 3, 4})
+        assertNoDeadlock(t, func(swapped bool, j int) {
+            a, b := s1, s2
+            if swapped {
+                a, b = s2, s1
+            }
+            a.Equal(b)
+            a.IsSubsetOf(b)
+            a.SymmetricDifference(b)
+            a.AddAll(b)
+            a.Add(j)
+            a.Remove(j)
+        })
+    })
+}
+
+func TestSet_LockOrder_SetOperations(t *testing.T) {
+    gtest.Case(t, func() {
+        s1 := gset.NewFrom([]int{1, 2, 3})
+        s2 := gset.NewFrom([]int{2, 3, 4})
+        assertNoDeadlock(t, func(swapped bool, j int) {
+            a, b := s1, s2
+            if swapped {
+                a, b = s2, s1
+            }
+            a.Union(b)
+            a.Diff(b)
+            a.Intersect(b)
+            a.Complement(b)
+            a.Merge(b)
+            a.Add(j)
+            a.Remove(j)
+        })
+    })
+}
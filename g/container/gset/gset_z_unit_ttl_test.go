@@ -0,0 +1,83 @@
+// Copyright 2019 gf Author(https://github.com/gogf/gf). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// go test *.go
+
+package gset_test
+
+import (
+    "github.com/gogf/gf/g/container/gset"
+    "github.com/gogf/gf/g/test/gtest"
+    "testing"
+    "time"
+)
+
+func TestTTLSet_Basic(t *testing.T) {
+    gtest.Case(t, func() {
+        s := gset.NewTTLSet(time.Hour)
+        gtest.Assert(s.TTL(), time.Hour)
+        s.Add(1, 2, 3)
+        gtest.Assert(s.Size(), 3)
+        gtest.Assert(s.Contains(1), true)
+        gtest.Assert(s.Contains(4), false)
+        gtest.AssertIN(2, s.Slice())
+        s.Remove(1)
+        gtest.Assert(s.Size(), 2)
+        gtest.Assert(s.Contains(1), false)
+        s.Clear()
+        gtest.Assert(s.Size(), 0)
+    })
+}
+
+// ttlClock returns a manually advanced clock for TTLSet, which avoids the flaky sleeping in tests.
+func ttlClock(s *gset.TTLSet) (advance func(d time.Duration)) {
+    now := time.Now()
+    s.SetClock(func() time.Time {
+        return now
+    })
+    return func(d time.Duration) {
+        now = now.Add(d)
+    }
+}
+
+func TestTTLSet_Expire(t *testing.T) {
+    gtest.Case(t, func() {
+        s       := gset.NewTTLSet(time.Minute)
+        advance := ttlClock(s)
+        s.Add(1, 2)
+        gtest.Assert(s.Contains(1), true)
+        advance(time.Minute)
+        s.Add(3)
+        gtest.Assert(s.Contains(1), false)
+        gtest.Assert(s.Contains(3), true)
+        gtest.Assert(s.Size(), 1)
+        gtest.Assert(s.Slice(), []interface{}{3})
+        // 过期的元素项已在添加时被删除.
+        gtest.Assert(s.Sweep(), 0)
+        gtest.Assert(s.Size(), 1)
+    })
+}
+
+func TestTTLSet_AddSweep(t *testing.T) {
+    gtest.Case(t, func() {
+        s       := gset.NewTTLSet(time.Minute)
+        advance := ttlClock(s)
+        s.Add(1, 2, 3)
+        advance(40*time.Second)
+        // 刷新元素项2的过期时间.
+        s.Add(2)
+        advance(40*time.Second)
+        // 添加时删除已过期的元素项1和3, 元素项2的旧记录被丢弃.
+        s.Add(4)
+        gtest.Assert(s.Sweep(), 0)
+        gtest.Assert(s.Contains(2), true)
+        gtest.Assert(s.Size(), 2)
+        s.Remove(4)
+        advance(time.Minute)
+        gtest.Assert(s.Sweep(), 1)
+        gtest.Assert(s.Size(), 0)
+    })
+}